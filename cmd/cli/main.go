@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/Asutorufa/yuhaiin/internal/api"
 	"github.com/Asutorufa/yuhaiin/internal/app"
 	"github.com/Asutorufa/yuhaiin/pkg/subscr"
 	"github.com/spf13/cobra"
@@ -61,7 +62,7 @@ func main() {
 		Long:  "",
 	}
 
-	rootCmd.AddCommand(nodeCmd(y), latencyCmd(y), streamCmd(y), subCmd(y))
+	rootCmd.AddCommand(nodeCmd(y), latencyCmd(y), streamCmd(y), subCmd(y), routeCmd(y), dnsCmd(y))
 	rootCmd.Execute()
 }
 
@@ -113,6 +114,50 @@ func subCmd(y *yhCli) *cobra.Command {
 
 	return subCmd
 }
+func routeCmd(y *yhCli) *cobra.Command {
+	routeCmd := &cobra.Command{
+		Use: "route",
+	}
+
+	test := &cobra.Command{
+		Use:   "test <host>",
+		Short: "explain the route of host or host:port",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			network, _ := cmd.Flags().GetString("network")
+			inbound, _ := cmd.Flags().GetString("inbound")
+			if err := y.route(inbound, network, args[0]); err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+	test.Flags().StringP("network", "n", "tcp", "tcp or udp")
+	test.Flags().StringP("inbound", "i", "", "the listener: socks5, http, redir")
+
+	routeCmd.AddCommand(test)
+
+	return routeCmd
+}
+
+func dnsCmd(y *yhCli) *cobra.Command {
+	dnsCmd := &cobra.Command{
+		Use: "dns",
+	}
+
+	stats := &cobra.Command{
+		Use:   "stats",
+		Short: "show the size and hit/miss counters of the dns caches and the health of the upstreams",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := y.dnsStats(); err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+
+	dnsCmd.AddCommand(stats)
+	return dnsCmd
+}
+
 func nodeCmd(y *yhCli) *cobra.Command {
 	nodeCmd := &cobra.Command{
 		Use: "node",
@@ -227,6 +272,7 @@ type yhCli struct {
 	conn *grpc.ClientConn
 	cm   app.ConnectionsClient
 	sub  subscr.NodeManagerClient
+	conf api.ConfigClient
 }
 
 func NewCli(host string) (*yhCli, error) {
@@ -237,7 +283,8 @@ func NewCli(host string) (*yhCli, error) {
 
 	cm := app.NewConnectionsClient(conn)
 	sub := subscr.NewNodeManagerClient(conn)
-	return &yhCli{conn: conn, cm: cm, sub: sub}, nil
+	conf := api.NewConfigClient(conn)
+	return &yhCli{conn: conn, cm: cm, sub: sub, conf: conf}, nil
 }
 
 func (y *yhCli) streamData() {
@@ -399,3 +446,44 @@ func (y *yhCli) nodeInfo(hash string) error {
 	fmt.Println(string(d))
 	return nil
 }
+
+func (y *yhCli) route(inbound, network, host string) error {
+	r, err := y.conf.Route(context.Background(), &api.RouteReq{Host: host, Network: network, Inbound: inbound})
+	if err != nil {
+		return fmt.Errorf("get route failed: %w", err)
+	}
+
+	fmt.Println("mode    ", r.Mode)
+	fmt.Println("outbound", r.Outbound)
+	switch {
+	case r.Rule == "":
+		fmt.Println("rule     none")
+	case r.File == "":
+		fmt.Println("rule    ", r.Rule)
+	default:
+		fmt.Printf("rule     %s (%s:%d)\n", r.Rule, r.File, r.Line)
+	}
+	fmt.Println("cache   ", r.Cache)
+	if r.Ip != "" {
+		fmt.Println("ip      ", r.Ip)
+	}
+	return nil
+}
+
+func (y *yhCli) dnsStats() error {
+	r, err := y.conf.DNSStats(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("get dns stats failed: %w", err)
+	}
+
+	for _, c := range r.Caches {
+		fmt.Printf("%s\n\tsize: %d, hits: %d, misses: %d\n", c.Name, c.Size, c.Hits, c.Misses)
+	}
+	for _, u := range r.Upstreams {
+		fmt.Printf("%s\n\tsuccess: %d, failure: %d, latency: %dms\n", u.Name, u.Success, u.Failure, u.Latency)
+		if u.Error != "" {
+			fmt.Printf("\tlast error: %s\n", u.Error)
+		}
+	}
+	return nil
+}
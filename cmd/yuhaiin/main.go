@@ -47,6 +47,9 @@ func defaultConfigDir() (Path string) {
 	return
 }
 
+//dnsManager the dns caches are saved before exit
+var dnsManager *app.DNSManager
+
 func init() {
 	log.SetFlags(log.Llongfile)
 
@@ -65,6 +68,11 @@ func init() {
 			switch s {
 			case syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
 				log.Println("kernel exit")
+				if dnsManager != nil {
+					if err := dnsManager.Save(); err != nil {
+						log.Printf("save dns cache failed: %v\n", err)
+					}
+				}
 				os.Exit(0)
 			default:
 				fmt.Println("OTHERS SIGN:", s)
@@ -106,6 +114,11 @@ func main() {
 		panic(err)
 	}
 
+	dnsManager = app.NewDNSManager(conf)
+	if err = dnsManager.Persist(*configDir, 5*time.Minute); err != nil {
+		log.Printf("load dns cache failed: %v\n", err)
+	}
+
 	/*
 	* net.Conn/net.PacketConn
 	*    |
@@ -120,6 +133,7 @@ func main() {
 
 	var nodeManager *subscr.NodeManager
 	var flowStatis *app.ConnManager
+	var bypass *app.BypassManager
 	// initialize Local Servers Controller
 	l, err := app.NewListener(conf, nil)
 	if err != nil {
@@ -129,10 +143,21 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
-		flowStatis = app.NewConnManager(app.NewBypassManager(conf, nodeManager))
+		bypass = app.NewBypassManager(conf, dnsManager, nodeManager)
+		flowStatis = app.NewConnManager(bypass)
 		l.SetProxy(flowStatis)
 	}
 
+	if _, err = app.NewFakeDNS(conf, dnsManager); err != nil {
+		log.Printf("create fake dns failed: %v\n", err)
+	}
+
+	if bypass != nil {
+		if _, err = app.NewDNSServer(conf, dnsManager, bypass, nodeManager); err != nil {
+			log.Printf("create dns server failed: %v\n", err)
+		}
+	}
+
 	lock := app.NewLock(filepath.Join(*configDir, "yuhaiin.lock"))
 	defer lock.UnLock()
 
@@ -142,11 +167,11 @@ func main() {
 	}
 	s := grpc.NewServer(grpc.EmptyServerOption{})
 
-	s.RegisterService(&api.Config_ServiceDesc, api.NewConfig(conf, flowStatis))  // TODO Deprecated
-	s.RegisterService(&api.Node_ServiceDesc, api.NewNode(nodeManager))           // TODO Deprecated
-	s.RegisterService(&api.Subscribe_ServiceDesc, api.NewSubscribe(nodeManager)) // TODO Deprecated
+	s.RegisterService(&api.Config_ServiceDesc, api.NewConfig(conf, flowStatis, bypass, dnsManager)) // TODO Deprecated
+	s.RegisterService(&api.Node_ServiceDesc, api.NewNode(nodeManager))                              // TODO Deprecated
+	s.RegisterService(&api.Subscribe_ServiceDesc, api.NewSubscribe(nodeManager))                    // TODO Deprecated
 
-	s.RegisterService(&api.ProcessInit_ServiceDesc, api.NewProcess(lock, *host))
+	s.RegisterService(&api.ProcessInit_ServiceDesc, api.NewProcess(lock, *host, dnsManager))
 	s.RegisterService(&subscr.NodeManager_ServiceDesc, nodeManager)
 	s.RegisterService(&config.ConfigDao_ServiceDesc, conf)
 	s.RegisterService(&app.Connections_ServiceDesc, flowStatis)
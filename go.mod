@@ -6,11 +6,13 @@ require (
 	github.com/golang/protobuf v1.5.2
 	github.com/gorilla/websocket v1.4.2
 	github.com/lucas-clemente/quic-go v0.19.3
+	github.com/oschwald/maxminddb-golang v1.8.0
 	github.com/rivo/tview v0.0.0-20210624165335-29d673af0ce2
 	github.com/shadowsocks/go-shadowsocks2 v0.1.3
 	github.com/spf13/cobra v1.2.1
 	github.com/v2rayA/shadowsocksR v1.0.2
 	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83
+	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4
 	google.golang.org/grpc v1.38.0
 	google.golang.org/protobuf v1.26.0
 )
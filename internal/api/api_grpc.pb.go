@@ -395,6 +395,10 @@ type ConfigClient interface {
 	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*config.Setting, error)
 	SetConfig(ctx context.Context, in *config.Setting, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ReimportRule(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// explain the routing decision of host:port
+	Route(ctx context.Context, in *RouteReq, opts ...grpc.CallOption) (*RouteResp, error)
+	// the statistics of the dns caches and the health of the dns upstreams
+	DNSStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSStatsResp, error)
 	GetRate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Config_GetRateClient, error)
 }
 
@@ -433,6 +437,24 @@ func (c *configClient) ReimportRule(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *configClient) Route(ctx context.Context, in *RouteReq, opts ...grpc.CallOption) (*RouteResp, error) {
+	out := new(RouteResp)
+	err := c.cc.Invoke(ctx, "/yuhaiin.api.config/Route", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) DNSStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSStatsResp, error) {
+	out := new(DNSStatsResp)
+	err := c.cc.Invoke(ctx, "/yuhaiin.api.config/DNSStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configClient) GetRate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Config_GetRateClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Config_ServiceDesc.Streams[0], "/yuhaiin.api.config/getRate", opts...)
 	if err != nil {
@@ -472,6 +494,10 @@ type ConfigServer interface {
 	GetConfig(context.Context, *emptypb.Empty) (*config.Setting, error)
 	SetConfig(context.Context, *config.Setting) (*emptypb.Empty, error)
 	ReimportRule(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// explain the routing decision of host:port
+	Route(context.Context, *RouteReq) (*RouteResp, error)
+	// the statistics of the dns caches and the health of the dns upstreams
+	DNSStats(context.Context, *emptypb.Empty) (*DNSStatsResp, error)
 	GetRate(*emptypb.Empty, Config_GetRateServer) error
 	mustEmbedUnimplementedConfigServer()
 }
@@ -489,6 +515,12 @@ func (UnimplementedConfigServer) SetConfig(context.Context, *config.Setting) (*e
 func (UnimplementedConfigServer) ReimportRule(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReimportRule not implemented")
 }
+func (UnimplementedConfigServer) Route(context.Context, *RouteReq) (*RouteResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Route not implemented")
+}
+func (UnimplementedConfigServer) DNSStats(context.Context, *emptypb.Empty) (*DNSStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DNSStats not implemented")
+}
 func (UnimplementedConfigServer) GetRate(*emptypb.Empty, Config_GetRateServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetRate not implemented")
 }
@@ -559,6 +591,42 @@ func _Config_ReimportRule_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Config_Route_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).Route(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yuhaiin.api.config/Route",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).Route(ctx, req.(*RouteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_DNSStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DNSStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/yuhaiin.api.config/DNSStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DNSStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Config_GetRate_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -599,6 +667,14 @@ var Config_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReimportRule",
 			Handler:    _Config_ReimportRule_Handler,
 		},
+		{
+			MethodName: "Route",
+			Handler:    _Config_Route_Handler,
+		},
+		{
+			MethodName: "DNSStats",
+			Handler:    _Config_DNSStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2,6 +2,7 @@ package api
 
 import (
 	context "context"
+	"fmt"
 	"log"
 
 	"github.com/Asutorufa/yuhaiin/internal/app"
@@ -15,10 +16,12 @@ type Config struct {
 	UnimplementedConfigServer
 	c           *config.Config
 	connManager *app.ConnManager
+	bypass      *app.BypassManager
+	dns         *app.DNSManager
 }
 
-func NewConfig(e *config.Config, ee *app.ConnManager) ConfigServer {
-	return &Config{c: e, connManager: ee}
+func NewConfig(e *config.Config, ee *app.ConnManager, b *app.BypassManager, d *app.DNSManager) ConfigServer {
+	return &Config{c: e, connManager: ee, bypass: b, dns: d}
 }
 
 func (c *Config) GetConfig(cc context.Context, e *emptypb.Empty) (*config.Setting, error) {
@@ -33,6 +36,52 @@ func (c *Config) ReimportRule(context.Context, *emptypb.Empty) (*emptypb.Empty,
 	return &emptypb.Empty{}, c.c.ExecCommand("RefreshMapping")
 }
 
+func (c *Config) Route(_ context.Context, req *RouteReq) (*RouteResp, error) {
+	if c.bypass == nil {
+		return nil, fmt.Errorf("bypass manager is not initialized")
+	}
+
+	r, err := c.bypass.Route(req.Inbound, req.Network, req.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RouteResp{
+		Mode:     app.ModeMapping[r.Mode],
+		Outbound: r.Outbound,
+		Rule:     r.Rule.Rule,
+		File:     r.File,
+		Line:     int64(r.Line),
+		Cache:    r.Cache,
+	}
+	if r.IP != nil {
+		resp.Ip = r.IP.String()
+	}
+	return resp, nil
+}
+
+func (c *Config) DNSStats(context.Context, *emptypb.Empty) (*DNSStatsResp, error) {
+	resp := &DNSStatsResp{}
+	for _, s := range c.dns.CacheStats() {
+		resp.Caches = append(resp.Caches, &DNSCacheStats{
+			Name:   s.Name,
+			Size:   int64(s.Size),
+			Hits:   s.Hits,
+			Misses: s.Misses,
+		})
+	}
+	for _, s := range c.dns.UpstreamStats() {
+		resp.Upstreams = append(resp.Upstreams, &DNSUpstreamStats{
+			Name:    s.Name,
+			Success: s.Success,
+			Failure: s.Failure,
+			Latency: s.Latency.Milliseconds(),
+			Error:   s.Error,
+		})
+	}
+	return resp, nil
+}
+
 func (c *Config) GetRate(_ *emptypb.Empty, srv Config_GetRateServer) error {
 	ct, cancel := context.WithCancel(context.Background())
 	defer cancel()
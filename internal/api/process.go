@@ -21,10 +21,11 @@ type Process struct {
 
 	locks *app.Lock
 	host  string
+	dns   *app.DNSManager
 }
 
-func NewProcess(lock *app.Lock, host string) ProcessInitServer {
-	return &Process{locks: lock, host: host}
+func NewProcess(lock *app.Lock, host string, dns *app.DNSManager) ProcessInitServer {
+	return &Process{locks: lock, host: host, dns: dns}
 }
 
 func (s *Process) CreateLockFile(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
@@ -99,5 +100,8 @@ func (s *Process) GetKernelPid(context.Context, *emptypb.Empty) (*wrapperspb.UIn
 
 func (s *Process) StopKernel(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	defer os.Exit(0)
+	if err := s.dns.Save(); err != nil {
+		log.Printf("save dns cache failed: %v\n", err)
+	}
 	return &emptypb.Empty{}, nil
 }
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+	"github.com/Asutorufa/yuhaiin/pkg/net/process"
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
 )
 
 type MODE int
@@ -17,61 +23,165 @@ const (
 	OTHERS MODE = 0
 	BLOCK  MODE = 1
 	DIRECT MODE = 2
-	// PROXY  MODE = 3
-	MAX MODE = 3
+	PROXY  MODE = 3
+	MAX    MODE = 4
 )
 
 var ModeMapping = map[MODE]string{
 	OTHERS: "proxy",
 	DIRECT: "direct",
 	BLOCK:  "block",
+	PROXY:  "proxy",
 }
 
 var Mode = map[string]MODE{
 	"direct": DIRECT,
-	// "proxy":  PROXY,
-	"block": BLOCK,
+	"proxy":  PROXY,
+	"block":  BLOCK,
+}
+
+//Target the action of a bypass rule,
+//for proxy mode, Hash or Group can specify the outbound node, otherwise use the now node
+type Target struct {
+	Mode  MODE
+	Hash  string
+	Group string
+}
+
+//ParseTarget parse the action of a bypass rule
+// eg: direct, block, proxy, proxy:<hash>, proxy:hash=<hash>, proxy:group=<group>
+func ParseTarget(s string) (Target, error) {
+	i := strings.IndexByte(s, ':')
+	if i == -1 {
+		i = len(s)
+	}
+
+	t := Target{Mode: Mode[strings.ToLower(s[:i])]}
+	if t.Mode == OTHERS {
+		return t, fmt.Errorf("unknown mode: %s", s[:i])
+	}
+
+	if i == len(s) {
+		return t, nil
+	}
+
+	if t.Mode != PROXY {
+		return t, fmt.Errorf("mode %s can't specify outbound: %s", s[:i], s)
+	}
+
+	outbound := s[i+1:]
+	switch {
+	case strings.HasPrefix(outbound, "group="):
+		t.Group = strings.TrimPrefix(outbound, "group=")
+	case strings.HasPrefix(outbound, "hash="):
+		t.Hash = strings.TrimPrefix(outbound, "hash=")
+	default:
+		t.Hash = outbound
+	}
+
+	if t.Group == "" && t.Hash == "" {
+		return t, fmt.Errorf("empty outbound: %s", s)
+	}
+	return t, nil
+}
+
+func (t Target) String() string {
+	switch {
+	case t.Hash != "":
+		return ModeMapping[t.Mode] + ":hash=" + t.Hash
+	case t.Group != "":
+		return ModeMapping[t.Mode] + ":group=" + t.Group
+	default:
+		return ModeMapping[t.Mode]
+	}
+}
+
+//Rule the matched bypass rule, File and Line are where the rule is from
+type Rule struct {
+	Target
+	Rule string
+	File string
+	Line int
+}
+
+func (r Rule) String() string {
+	if r.Rule == "" {
+		return r.Target.String()
+	}
+	if r.File == "" {
+		return fmt.Sprintf("%s (%s)", r.Target, r.Rule)
+	}
+	return fmt.Sprintf("%s (%s, %s:%d)", r.Target, r.Rule, r.File, r.Line)
+}
+
+//Outbounder get the proxy of specified node
+type Outbounder interface {
+	Outbound(hash, group string) (proxy.Proxy, error)
 }
 
 //BypassManager .
 type BypassManager struct {
-	mapper func(string) MODE
-	proxy  proxy.Proxy
-	dialer *net.Dialer
-	bypass bool
+	mapper   func(network, addr string, owner mapper.Owner) Rule
+	explain  func(network, addr string) (Rule, bool, net.IP)
+	domain   func(domain string) Rule
+	proxy    proxy.Proxy
+	outbound Outbounder
+	dialer   *net.Dialer
+	bypass   bool
+	hosts    *dns.Hosts
+
+	policies     map[string]*policy
+	policiesLock sync.RWMutex
 }
 
 var ErrBlockAddr = errors.New("BLOCK ADDRESS")
 
-//NewBypassManager .
-func NewBypassManager(conf *config.Config, p proxy.Proxy) *BypassManager {
+var _ proxy.MetadataProxy = (*BypassManager)(nil)
+
+//NewBypassManager d: the dns clients of the rules and the dialer
+func NewBypassManager(conf *config.Config, d *DNSManager, p proxy.Proxy) *BypassManager {
 	if p == nil {
 		p = &proxy.DefaultProxy{}
 	}
 
-	shunt, err := NewShunt(conf)
+	shunt, err := NewShunt(conf, d, p)
 	if err != nil {
 		log.Printf("create shunt failed: %v, disable bypass.\n", err)
 	}
 
-	m := &BypassManager{proxy: p, mapper: shunt.Get}
+	m := &BypassManager{proxy: p, mapper: shunt.Get, explain: shunt.Explain, domain: shunt.SearchDomain, hosts: d.hosts}
+	if o, ok := p.(Outbounder); ok {
+		m.outbound = o
+	}
 
 	_ = conf.Exec(
 		func(s *config.Setting) error {
 			m.dialer = &net.Dialer{
 				Timeout:  11 * time.Second,
-				Resolver: getDNS(s.LocalDNS).Resolver(),
+				Resolver: d.get(s.LocalDNS, p, s.Bypass.GeoipFile).Resolver(),
 			}
+			setBootstrap(d, s.LocalDNS, s.Bypass.GeoipFile)
 			m.bypass = s.Bypass.Enabled
+			m.policies = newPolicies(s, d, p)
 			return nil
 		})
 
+	conf.AddObserver(func(current, old *config.Setting) {
+		if diffPolicies(old, current) {
+			ps := newPolicies(current, d, p)
+			m.policiesLock.Lock()
+			m.policies = ps
+			m.policiesLock.Unlock()
+		}
+	})
+
 	conf.AddObserver(func(current, old *config.Setting) {
 		if diffDNS(old.LocalDNS, current.LocalDNS) {
 			m.dialer = &net.Dialer{
 				Timeout:  8 * time.Second,
-				Resolver: getDNS(current.LocalDNS).Resolver(),
+				Resolver: d.get(current.LocalDNS, p, current.Bypass.GeoipFile).Resolver(),
 			}
+			setBootstrap(d, current.LocalDNS, current.Bypass.GeoipFile)
 		}
 	})
 
@@ -84,50 +194,200 @@ func NewBypassManager(conf *config.Config, p proxy.Proxy) *BypassManager {
 	return m
 }
 
+//setBootstrap the server addresses of the nodes are resolved by the local dns directly,
+//the dns through the proxy can't be used, resolving the server of the node itself loops
+func setBootstrap(d *DNSManager, dc *config.DNS, geoipFile string) {
+	utils.SetStrategy(utils.Strategy(dc.Strategy))
+	utils.SetBootstrap(d.get(dc, nil, geoipFile).LookupIP)
+}
+
 //Conn get net.Conn by host
 func (m *BypassManager) Conn(host string) (conn net.Conn, err error) {
-	resp, err := m.marry(host)
+	return m.ConnWithMetadata(host, nil)
+}
+
+func (m *BypassManager) PacketConn(host string) (conn net.PacketConn, err error) {
+	return m.PacketConnWithMetadata(host, nil)
+}
+
+//ConnWithMetadata get net.Conn by host and the metadata of the inbound connection,
+//the rules match the host, the address of the hosts is dialed
+func (m *BypassManager) ConnWithMetadata(host string, md *proxy.Metadata) (net.Conn, error) {
+	resp, err := m.marry("tcp", host, md)
 	if err != nil {
 		return nil, fmt.Errorf("map failed: %v", err)
 	}
 
-	return resp.Conn(host)
-
+	return resp.Conn(m.hosts.Dial(host))
 }
 
-func (m *BypassManager) PacketConn(host string) (conn net.PacketConn, err error) {
-	resp, err := m.marry(host)
+func (m *BypassManager) PacketConnWithMetadata(host string, md *proxy.Metadata) (net.PacketConn, error) {
+	resp, err := m.marry("udp", host, md)
 	if err != nil {
 		return nil, fmt.Errorf("map failed: %v", err)
 	}
-	return resp.PacketConn(host)
+	return resp.PacketConn(m.hosts.Dial(host))
 }
 
-func (m *BypassManager) marry(host string) (p proxy.Proxy, err error) {
-	hostname, _, err := net.SplitHostPort(host)
+func (m *BypassManager) marry(network, host string, md *proxy.Metadata) (p proxy.Proxy, err error) {
+	_, _, err = net.SplitHostPort(host)
 	if err != nil {
 		return nil, fmt.Errorf("split host [%s] failed: %v", host, err)
 	}
 
-	mark := OTHERS
-	if m.mapper != nil && m.bypass {
-		mark = m.mapper(hostname)
-	}
+	mark := m.rule(network, host, md)
 
-	fmt.Printf("[%s] ->  mode: %s\n", host, ModeMapping[mark])
+	log.Printf("[%s://%s] -> mode: %s", network, host, mark)
 
-	switch mark {
+	switch mark.Mode {
 	case BLOCK:
 		err = fmt.Errorf("%w: %v", ErrBlockAddr, host)
 	case DIRECT:
 		p = &direct{dialer: m.dialer}
 	default:
-		p = m.proxy
+		p, err = m.getOutbound(mark.Target)
 	}
 
 	return
 }
 
+//rule get the rule by the policy of the inbound listener, or the default bypass
+func (m *BypassManager) rule(network, host string, md *proxy.Metadata) Rule {
+	get := m.mapper
+	if p := m.policy(md); p != nil {
+		if p.target != nil {
+			return Rule{Target: *p.target, Rule: "inbound:" + md.Inbound}
+		}
+		get = p.shunt.Get
+	}
+
+	if get == nil || !m.bypass {
+		return Rule{Target: Target{Mode: OTHERS}}
+	}
+	return get(network, host, owner(network, md))
+}
+
+func (m *BypassManager) policy(md *proxy.Metadata) *policy {
+	if md == nil || md.Inbound == "" {
+		return nil
+	}
+	m.policiesLock.RLock()
+	defer m.policiesLock.RUnlock()
+	return m.policies[md.Inbound]
+}
+
+//owner find the process of the inbound connection from localhost, it is called only when the process rules are evaluated
+func owner(network string, md *proxy.Metadata) mapper.Owner {
+	if md == nil || md.Source == nil {
+		return nil
+	}
+
+	return func() (string, uint32, bool) {
+		host, _, err := net.SplitHostPort(md.Source.String())
+		if err != nil {
+			return "", 0, false
+		}
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return "", 0, false
+		}
+
+		p, err := process.FindProcess(network, md.Source)
+		if err != nil {
+			log.Printf("find process of %s failed: %v", md.Source, err)
+		}
+		if p == nil {
+			return "", 0, false
+		}
+		return p.Name, p.UID, true
+	}
+}
+
+//DomainRule the rule of the domain by the domain rules only, the ip, port and process rules are ignored and the domain isn't resolved,
+//inbound: the name of listener, can be empty
+func (m *BypassManager) DomainRule(inbound, domain string) Rule {
+	search := m.domain
+	if p := m.policy(&proxy.Metadata{Inbound: inbound}); p != nil {
+		if p.target != nil {
+			return Rule{Target: *p.target, Rule: "inbound:" + inbound}
+		}
+		search = p.shunt.SearchDomain
+	}
+
+	if search == nil || !m.bypass {
+		return Rule{Target: Target{Mode: OTHERS}}
+	}
+	return search(domain)
+}
+
+//Route the routing decision of a host
+type Route struct {
+	Rule
+	// Outbound direct, block, now node, hash=<hash> or group=<group>
+	Outbound string
+	// Cache the result is answered by the lru cache of mapper
+	Cache bool
+	// IP the resolved ip used for cidr matching
+	IP net.IP
+}
+
+//Route explain why the host go direct, proxy or block,
+//inbound: the name of listener, can be empty, network: tcp or udp, host can be host:port or host
+func (m *BypassManager) Route(inbound, network, host string) (Route, error) {
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+	if hostname == "" {
+		return Route{}, fmt.Errorf("empty host: %s", host)
+	}
+	if network == "" {
+		network = "tcp"
+	}
+
+	explain := m.explain
+	r := Route{Rule: Rule{Target: Target{Mode: OTHERS}}}
+	if p := m.policy(&proxy.Metadata{Inbound: inbound}); p != nil {
+		if p.target != nil {
+			r.Rule = Rule{Target: *p.target, Rule: "inbound:" + inbound}
+			explain = nil
+		} else {
+			explain = p.shunt.Explain
+		}
+	}
+
+	if explain != nil && m.bypass {
+		r.Rule, r.Cache, r.IP = explain(network, host)
+	}
+
+	switch {
+	case r.Mode == DIRECT || r.Mode == BLOCK:
+		r.Outbound = ModeMapping[r.Mode]
+	case r.Hash != "":
+		r.Outbound = "hash=" + r.Hash
+	case r.Group != "":
+		r.Outbound = "group=" + r.Group
+	default:
+		r.Outbound = "now node"
+	}
+	return r, nil
+}
+
+func (m *BypassManager) getOutbound(t Target) (proxy.Proxy, error) {
+	if t.Hash == "" && t.Group == "" {
+		return m.proxy, nil
+	}
+
+	if m.outbound == nil {
+		return nil, fmt.Errorf("can't get outbound %v: no outbound provider", t)
+	}
+
+	p, err := m.outbound.Outbound(t.Hash, t.Group)
+	if err != nil {
+		return nil, fmt.Errorf("get outbound %v failed: %w", t, err)
+	}
+	return p, nil
+}
+
 type direct struct {
 	dialer *net.Dialer
 }
@@ -136,6 +396,15 @@ func (d *direct) Conn(s string) (net.Conn, error) {
 	return d.dialer.Dial("tcp", s)
 }
 
+//PacketConn the domain addresses written to are resolved by the local dns
 func (d *direct) PacketConn(string) (net.PacketConn, error) {
-	return net.ListenPacket("udp", "")
+	pc, err := net.ListenPacket("udp", "")
+	if err != nil {
+		return nil, err
+	}
+	var r *net.Resolver
+	if d.dialer != nil {
+		r = d.dialer.Resolver
+	}
+	return proxy.NewResolvePacketConn(pc, r), nil
 }
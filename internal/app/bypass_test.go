@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/url"
+	"os"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
 )
 
 func TestReadline(t *testing.T) {
@@ -210,3 +216,106 @@ func TestErr(t *testing.T) {
 
 	t.Log(a)
 }
+
+func TestParseTarget(t *testing.T) {
+	for _, x := range []struct {
+		str    string
+		target Target
+		err    bool
+	}{
+		{"DIRECT", Target{Mode: DIRECT}, false},
+		{"block", Target{Mode: BLOCK}, false},
+		{"PROXY", Target{Mode: PROXY}, false},
+		{"proxy:group=JP", Target{Mode: PROXY, Group: "JP"}, false},
+		{"proxy:hash=abc", Target{Mode: PROXY, Hash: "abc"}, false},
+		{"proxy:abc", Target{Mode: PROXY, Hash: "abc"}, false},
+		{"proxy:", Target{Mode: PROXY}, true},
+		{"direct:group=JP", Target{Mode: DIRECT}, true},
+		{"others", Target{}, true},
+	} {
+		z, err := ParseTarget(x.str)
+		if (err != nil) != x.err {
+			t.Errorf("parse %s, want err: %v, got: %v", x.str, x.err, err)
+			continue
+		}
+		if err == nil && z != x.target {
+			t.Errorf("parse %s, want: %v, got: %v", x.str, x.target, z)
+		}
+	}
+}
+
+func TestRoute(t *testing.T) {
+	m := &BypassManager{
+		bypass: true,
+		explain: func(network, host string) (Rule, bool, net.IP) {
+			if host != "www.example.com:443" {
+				return Rule{}, false, nil
+			}
+			return Rule{Target: Target{Mode: PROXY, Group: "hk"}, Rule: "*.example.com", File: "a.conf", Line: 3}, true, nil
+		},
+	}
+
+	r, err := m.Route("", "tcp", "www.example.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Outbound != "group=hk" || r.Line != 3 || !r.Cache {
+		t.Errorf("want group=hk from line 3, got %v", r)
+	}
+
+	r, err = m.Route("", "", "www.google.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Mode != OTHERS || r.Outbound != "now node" {
+		t.Errorf("want now node, got %v", r)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	f, err := ioutil.TempFile("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString("*.example.com direct\n")
+	f.Close()
+
+	ps := newPolicies(&config.Setting{
+		Bypass: &config.Bypass{},
+		DNS:    &config.DNS{Host: "127.0.0.1:53"},
+		Proxy: &config.Proxy{
+			Policies: map[string]*config.InboundPolicy{
+				"socks5": {Outbound: "proxy:group=hk"},
+				"http":   {BypassFile: f.Name()},
+				"redir":  {Outbound: "unknown"},
+			},
+		},
+	}, newDNSManager(), nil)
+	if len(ps) != 2 {
+		t.Fatalf("want 2 policies, got %d", len(ps))
+	}
+
+	m := &BypassManager{
+		bypass:   true,
+		policies: ps,
+		mapper: func(string, string, mapper.Owner) Rule {
+			return Rule{Target: Target{Mode: BLOCK}}
+		},
+	}
+
+	for _, v := range []struct {
+		inbound string
+		want    Target
+	}{
+		{"socks5", Target{Mode: PROXY, Group: "hk"}},
+		{"http", Target{Mode: DIRECT}},
+		{"redir", Target{Mode: BLOCK}},
+		{"", Target{Mode: BLOCK}},
+	} {
+		r := m.rule("tcp", "www.example.com:443", &proxy.Metadata{Inbound: v.inbound})
+		if r.Target != v.want {
+			t.Errorf("inbound %s: want %v, got %v", v.inbound, v.want, r)
+		}
+	}
+}
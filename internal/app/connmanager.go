@@ -15,7 +15,7 @@ import (
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var _ proxy.Proxy = (*ConnManager)(nil)
+var _ proxy.MetadataProxy = (*ConnManager)(nil)
 var _ ConnectionsServer = (*ConnManager)(nil)
 
 type ConnManager struct {
@@ -177,12 +177,20 @@ func (c *ConnManager) newPacketConn(addr string, x net.PacketConn) net.PacketCon
 }
 
 func (c *ConnManager) Conn(host string) (net.Conn, error) {
-	conn, err := c.proxy.Conn(host)
-	return c.newConn(host, conn), err
+	return c.ConnWithMetadata(host, nil)
 }
 
 func (c *ConnManager) PacketConn(host string) (net.PacketConn, error) {
-	conn, err := c.proxy.PacketConn(host)
+	return c.PacketConnWithMetadata(host, nil)
+}
+
+func (c *ConnManager) ConnWithMetadata(host string, m *proxy.Metadata) (net.Conn, error) {
+	conn, err := proxy.ConnWithMetadata(c.proxy, host, m)
+	return c.newConn(host, conn), err
+}
+
+func (c *ConnManager) PacketConnWithMetadata(host string, m *proxy.Metadata) (net.PacketConn, error) {
+	conn, err := proxy.PacketConnWithMetadata(c.proxy, host, m)
 	return c.newPacketConn(host, conn), err
 }
 
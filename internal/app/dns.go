@@ -0,0 +1,414 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns/doq"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+func diffDNS(old, new *config.DNS) bool {
+	if old.Host != new.Host {
+		return true
+	}
+	if dnsType(old) != dnsType(new) {
+		return true
+	}
+	if old.Subnet != new.Subnet {
+		return true
+	}
+	if old.Strategy != new.Strategy {
+		return true
+	}
+	if old.Proxy != new.Proxy || old.Outbound != new.Outbound {
+		return true
+	}
+	if old.Mode != new.Mode || strings.Join(old.FallbackFilter, ",") != strings.Join(new.FallbackFilter, ",") {
+		return true
+	}
+	if old.Dnssec != new.Dnssec || strings.Join(old.TrustAnchors, ",") != strings.Join(new.TrustAnchors, ",") {
+		return true
+	}
+	if old.CacheStale != new.CacheStale {
+		return true
+	}
+	if len(old.Upstreams) != len(new.Upstreams) {
+		return true
+	}
+	for i := range old.Upstreams {
+		if !proto.Equal(old.Upstreams[i], new.Upstreams[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+//DNSManager create the dns clients of the setting, the clients of the same server share the cache,
+//the upstream health and the fallback filter owned by it, the ones no longer used by the setting are dropped when the dns setting changes
+type DNSManager struct {
+	// hosts the hosts of the setting, shared by the dns clients and the bypass
+	hosts *dns.Hosts
+	// caches key: dnsCacheKey, healths key: dnsUpstreamName, trusts key: dnsTrustKey
+	caches  map[string]*dns.Cache
+	healths map[string]*dns.Health
+	trusts  map[string]func(net.IP) bool
+	lock    sync.Mutex
+
+	store dnsCacheStore
+}
+
+func newDNSManager() *DNSManager {
+	return &DNSManager{
+		hosts:   dns.NewHosts(nil),
+		caches:  make(map[string]*dns.Cache),
+		healths: make(map[string]*dns.Health),
+		trusts:  make(map[string]func(net.IP) bool),
+	}
+}
+
+//NewDNSManager the hosts follow the setting, the unused caches, healths and filters are dropped after the dns setting changed
+func NewDNSManager(conf *config.Config) *DNSManager {
+	d := newDNSManager()
+	_ = conf.Exec(func(s *config.Setting) error {
+		d.hosts.Set(s.Hosts)
+		return nil
+	})
+
+	conf.AddObserver(func(current, old *config.Setting) {
+		if !reflect.DeepEqual(current.Hosts, old.Hosts) {
+			d.hosts.Set(current.Hosts)
+		}
+	})
+
+	conf.AddObserver(func(current, old *config.Setting) {
+		if diffDNS(current.DNS, old.DNS) || diffDNS(current.LocalDNS, old.LocalDNS) ||
+			current.Bypass.GetGeoipFile() != old.Bypass.GetGeoipFile() {
+			d.prune(current)
+		}
+	})
+	return d
+}
+
+//prune drop the caches, healths and filters not used by the dns and local dns of s
+func (d *DNSManager) prune(s *config.Setting) {
+	caches, healths, trusts := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, dc := range []*config.DNS{s.DNS, s.LocalDNS} {
+		if dc == nil {
+			continue
+		}
+		caches[dnsCacheKey(dc)] = true
+		if len(dc.Upstreams) != 0 {
+			if dc.Host != "" {
+				healths[fmt.Sprintf("%s://%s", dnsType(dc), dc.Host)] = true
+			}
+			for _, u := range dc.Upstreams {
+				healths[dnsUpstreamName(u)] = true
+			}
+		}
+		if dc.Mode == config.DNS_fallback {
+			trusts[dnsTrustKey(dc.FallbackFilter, s.Bypass.GetGeoipFile())] = true
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for k := range d.caches {
+		if !caches[k] {
+			delete(d.caches, k)
+		}
+	}
+	for k := range d.healths {
+		if !healths[k] {
+			delete(d.healths, k)
+		}
+	}
+	for k := range d.trusts {
+		if !trusts[k] {
+			delete(d.trusts, k)
+		}
+	}
+}
+
+func dnsCacheKey(dc *config.DNS) string {
+	key := fmt.Sprintf("%s://%s", dnsType(dc), dc.Host)
+	if len(dc.Upstreams) != 0 {
+		hosts := []string{key}
+		for _, u := range dc.Upstreams {
+			hosts = append(hosts, dnsUpstreamName(u))
+		}
+		key = fmt.Sprintf("%s [%s]", dns.Mode(dc.Mode), strings.Join(hosts, ", "))
+	}
+
+	// the answers differ by the client subnet and the route of the queries
+	attrs := []string{utils.Strategy(dc.Strategy).String()}
+	if dc.Subnet != "" {
+		attrs = append(attrs, "subnet "+dc.Subnet)
+	}
+	if dc.Proxy && dc.Outbound != "" {
+		attrs = append(attrs, "proxy "+dc.Outbound)
+	} else if dc.Proxy {
+		attrs = append(attrs, "proxy")
+	}
+	if dc.Dnssec {
+		// the validated answers don't share the cache with the unvalidated ones
+		attrs = append(attrs, "dnssec")
+	}
+	if dc.CacheStale != 0 {
+		attrs = append(attrs, "stale "+dnsStale(dc).String())
+	}
+	return fmt.Sprintf("%s (%s)", key, strings.Join(attrs, ", "))
+}
+
+func (d *DNSManager) cache(dc *config.DNS) *dns.Cache {
+	key := dnsCacheKey(dc)
+
+	d.lock.Lock()
+	c, ok := d.caches[key]
+	if !ok {
+		c = dns.NewCache(1024)
+		c.Stale = dnsStale(dc)
+		d.caches[key] = c
+	}
+	d.lock.Unlock()
+
+	if !ok {
+		d.restore(key, c)
+	}
+	return c
+}
+
+//DNSCacheStats the statistics of the dns cache of a server
+type DNSCacheStats struct {
+	// Name the host and strategy of the server
+	Name string
+	dns.CacheStats
+}
+
+//CacheStats get the statistics of the dns caches, sorted by name
+func (d *DNSManager) CacheStats() []DNSCacheStats {
+	d.lock.Lock()
+	s := make([]DNSCacheStats, 0, len(d.caches))
+	for k, v := range d.caches {
+		s = append(s, DNSCacheStats{Name: k, CacheStats: v.Stats()})
+	}
+	d.lock.Unlock()
+	sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+	return s
+}
+
+//DNSUpstreamStats the health of an upstream of the dns groups
+type DNSUpstreamStats struct {
+	// Name the type and host of the upstream
+	Name string
+	dns.HealthStats
+}
+
+//health the upstreams of the same server share one health
+func (d *DNSManager) health(name string) *dns.Health {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	h, ok := d.healths[name]
+	if !ok {
+		h = &dns.Health{}
+		d.healths[name] = h
+	}
+	return h
+}
+
+//UpstreamStats get the health of the upstreams of the dns groups, sorted by name
+func (d *DNSManager) UpstreamStats() []DNSUpstreamStats {
+	d.lock.Lock()
+	s := make([]DNSUpstreamStats, 0, len(d.healths))
+	for k, v := range d.healths {
+		s = append(s, DNSUpstreamStats{Name: k, HealthStats: v.Stats()})
+	}
+	d.lock.Unlock()
+	sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+	return s
+}
+
+func dnsUpstreamName(u *config.DNSUpstream) string {
+	t := u.Type
+	if t == config.DNS_reserve {
+		t = config.DNS_udp
+	}
+	return fmt.Sprintf("%s://%s", t, u.Host)
+}
+
+//get create the dns client, p: the now node, used only if the proxy of dns is enabled, nil for direct,
+//geoipFile: for the geoip rules of the fallback filter
+func (d *DNSManager) get(dc *config.DNS, p proxy.Proxy, geoipFile string) dns.DNS {
+	p = dnsProxy(dc, p)
+
+	_, subnet, err := net.ParseCIDR(dc.Subnet)
+	if err != nil {
+		if net.ParseIP(dc.Subnet).To4() != nil {
+			_, subnet, _ = net.ParseCIDR(dc.Subnet + "/32")
+		}
+
+		if net.ParseIP(dc.Subnet).To16() != nil {
+			_, subnet, _ = net.ParseCIDR(dc.Subnet + "/128")
+		}
+	}
+	opts := []dns.Option{dns.WithStrategy(utils.Strategy(dc.Strategy)), dns.WithCache(d.cache(dc)), dns.WithHosts(d.hosts)}
+	if !dc.Dnssec {
+		return d.group(dc, subnet, p, geoipFile, opts...)
+	}
+
+	// the validator queries the client directly, the lookup cache is used by the wrapper
+	g := d.group(dc, subnet, p, geoipFile, dns.WithStrategy(utils.Strategy(dc.Strategy)))
+	v, err := dns.NewDNSSEC(g, subnet, dc.TrustAnchors, opts...)
+	if err != nil {
+		log.Printf("invalid dnssec trust anchors: %v, use the root anchors", err)
+		v, _ = dns.NewDNSSEC(g, subnet, nil, opts...)
+	}
+	return v
+}
+
+//group create the client of the host, or the group if the upstreams are set
+func (d *DNSManager) group(dc *config.DNS, subnet *net.IPNet, p proxy.Proxy, geoipFile string, opts ...dns.Option) dns.DNS {
+	if len(dc.Upstreams) == 0 {
+		return newDNS(dnsType(dc), dc.Host, subnet, p, opts...)
+	}
+
+	us := make([]dns.Upstream, 0, len(dc.Upstreams)+1)
+	if dc.Host != "" {
+		name := fmt.Sprintf("%s://%s", dnsType(dc), dc.Host)
+		us = append(us, dns.Upstream{Name: name, DNS: newDNS(dnsType(dc), dc.Host, subnet, p), Health: d.health(name)})
+	}
+	for _, u := range dc.Upstreams {
+		name := dnsUpstreamName(u)
+		t := u.Type
+		if t == config.DNS_reserve {
+			t = config.DNS_udp
+		}
+		us = append(us, dns.Upstream{Name: name, DNS: newDNS(t, u.Host, subnet, p), Health: d.health(name)})
+	}
+	if dc.Mode == config.DNS_fallback {
+		opts = append(opts, dns.WithTrust(d.trust(dc.FallbackFilter, geoipFile)))
+	}
+	return dns.NewGroup(dns.Mode(dc.Mode), subnet, us, opts...)
+}
+
+func newDNS(t config.DNS_Type, host string, subnet *net.IPNet, p proxy.Proxy, opts ...dns.Option) dns.DNS {
+	switch t {
+	case config.DNS_tcp:
+		return dns.NewTCP(host, subnet, p, opts...)
+	case config.DNS_dot:
+		return dns.NewDoT(host, subnet, p, opts...)
+	case config.DNS_doh:
+		return dns.NewDoH(host, subnet, p, opts...)
+	case config.DNS_doq:
+		return doq.New(host, subnet, p, opts...)
+	default:
+		return dns.NewDNS(host, subnet, p, opts...)
+	}
+}
+
+func dnsTrustKey(rules []string, geoipFile string) string {
+	return geoipFile + "|" + strings.Join(rules, ",")
+}
+
+//trust the ips matched the rules are trusted, the rules are geoip or cidr rules of the bypass, nil if no rules,
+//the filters of the same rules share one mapper
+func (d *DNSManager) trust(rules []string, geoipFile string) func(net.IP) bool {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	key := dnsTrustKey(rules, geoipFile)
+	d.lock.Lock()
+	f, ok := d.trusts[key]
+	d.lock.Unlock()
+	if ok {
+		return f
+	}
+
+	m := mapper.NewMapper(nil)
+	for _, r := range rules {
+		if r = strings.TrimSpace(r); r != "" {
+			m.Insert(r, true)
+		}
+	}
+	if countries := m.GeoIPCountries(); len(countries) != 0 {
+		if geoipFile == "" {
+			log.Printf("geoip file is not set, ignore the geoip rules of the fallback filter: %v", countries)
+		} else if g, err := mapper.NewGeoIP(geoipFile, countries...); err != nil {
+			log.Printf("load geoip file failed: %v, ignore the geoip rules of the fallback filter", err)
+		} else {
+			m.SetGeoIP(g)
+		}
+	}
+
+	f = func(ip net.IP) bool { return m.Search(ip.String()) != nil }
+	d.lock.Lock()
+	d.trusts[key] = f
+	d.lock.Unlock()
+	return f
+}
+
+//dnsType the old config uses the doh flag
+func dnsType(dc *config.DNS) config.DNS_Type {
+	if dc.Type != config.DNS_reserve {
+		return dc.Type
+	}
+	if dc.DOH {
+		return config.DNS_doh
+	}
+	return config.DNS_udp
+}
+
+//dnsProxy get the proxy of the dns by the proxy and outbound setting
+func dnsProxy(dc *config.DNS, p proxy.Proxy) proxy.Proxy {
+	if !dc.Proxy || p == nil {
+		return nil
+	}
+	if dc.Outbound == "" {
+		return p
+	}
+
+	o, ok := p.(Outbounder)
+	if !ok {
+		log.Printf("dns outbound %s is ignored: no outbound provider", dc.Outbound)
+		return p
+	}
+	t, err := ParseTarget("proxy:" + dc.Outbound)
+	if err != nil {
+		log.Printf("parse dns outbound failed: %v, use the now node", err)
+		return p
+	}
+	return &outboundProxy{outbound: o, target: t}
+}
+
+//outboundProxy get the proxy of the outbound on every dial, so it follows the node changes
+type outboundProxy struct {
+	outbound Outbounder
+	target   Target
+}
+
+func (o *outboundProxy) Conn(host string) (net.Conn, error) {
+	p, err := o.outbound.Outbound(o.target.Hash, o.target.Group)
+	if err != nil {
+		return nil, fmt.Errorf("get outbound %v failed: %w", o.target, err)
+	}
+	return p.Conn(host)
+}
+
+func (o *outboundProxy) PacketConn(host string) (net.PacketConn, error) {
+	p, err := o.outbound.Outbound(o.target.Hash, o.target.Group)
+	if err != nil {
+		return nil, fmt.Errorf("get outbound %v failed: %w", o.target, err)
+	}
+	return p.PacketConn(host)
+}
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//mockOutbound record the dialed hosts and the required outbounds
+type mockOutbound struct {
+	proxy.DefaultProxy
+	conns     []string
+	outbounds []string
+}
+
+func (m *mockOutbound) Conn(host string) (net.Conn, error) {
+	m.conns = append(m.conns, host)
+	return m.DefaultProxy.Conn(host)
+}
+
+func (m *mockOutbound) Outbound(hash, group string) (proxy.Proxy, error) {
+	m.outbounds = append(m.outbounds, hash+"/"+group)
+	return m, nil
+}
+
+func TestDNSProxy(t *testing.T) {
+	s, err := dns.NewServer("127.0.0.1:15355", func(req []byte) ([]byte, error) {
+		var msg dnsmessage.Message
+		if err := msg.Unpack(req); err != nil {
+			return nil, err
+		}
+		msg.Response = true
+		q := msg.Questions[0]
+		if q.Type == dnsmessage.TypeA {
+			msg.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60},
+				Body:   &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+			}}
+		}
+		return msg.Pack()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for _, v := range []struct {
+		dc        *config.DNS
+		conns     int
+		outbounds []string
+	}{
+		{&config.DNS{Host: "127.0.0.1:15355", Type: config.DNS_tcp}, 0, nil},
+		// the A and AAAA queries
+		{&config.DNS{Host: "127.0.0.1:15355", Type: config.DNS_tcp, Proxy: true}, 2, nil},
+		{&config.DNS{Host: "127.0.0.1:15355", Type: config.DNS_tcp, Proxy: true, Outbound: "group=dns"}, 2, []string{"/dns", "/dns"}},
+	} {
+		m := &mockOutbound{}
+		ips, err := newDNSManager().get(v.dc, m, "").LookupIP("proxy.example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ips) != 1 || !ips[0].Equal(net.ParseIP("1.2.3.4")) {
+			t.Errorf("want [1.2.3.4], got %v", ips)
+		}
+		if len(m.conns) != v.conns || strings.Join(m.outbounds, ",") != strings.Join(v.outbounds, ",") {
+			t.Errorf("%v: want %d conns through %v, got %v through %v", v.dc, v.conns, v.outbounds, m.conns, m.outbounds)
+		}
+	}
+}
+
+func TestDNSTrust(t *testing.T) {
+	d := newDNSManager()
+	if d.trust(nil, "") != nil {
+		t.Error("want nil filter without rules")
+	}
+
+	f := d.trust([]string{"10.0.0.0/8", " 2001:db8::/32", "geoip:cn"}, "")
+	for ip, want := range map[string]bool{
+		"10.2.2.1":    true,
+		"2001:db8::1": true,
+		"1.1.1.1":     false,
+	} {
+		if f(net.ParseIP(ip)) != want {
+			t.Errorf("%s: want %v", ip, want)
+		}
+	}
+}
+
+func TestDNSGroup(t *testing.T) {
+	dc := &config.DNS{
+		Host:      "127.0.0.1:15356",
+		Type:      config.DNS_tcp,
+		Upstreams: []*config.DNSUpstream{{Host: "127.0.0.1:15357"}},
+		Mode:      config.DNS_race,
+	}
+	if _, ok := newDNSManager().get(dc, nil, "").(*dns.Group); !ok {
+		t.Fatal("want dns group with upstreams")
+	}
+	t.Log(dnsCacheKey(dc))
+	if x := dnsCacheKey(dc); x != "race [tcp://127.0.0.1:15356, udp://127.0.0.1:15357] (prefer-ipv4)" {
+		t.Errorf("unexpected cache key: %s", x)
+	}
+}
+
+func TestDNSCacheKey(t *testing.T) {
+	for want, dc := range map[string]*config.DNS{
+		"udp://127.0.0.1:53 (prefer-ipv4)":                                     {Host: "127.0.0.1:53"},
+		"udp://127.0.0.1:53 (prefer-ipv4, subnet 1.2.3.0/24)":                  {Host: "127.0.0.1:53", Subnet: "1.2.3.0/24"},
+		"udp://127.0.0.1:53 (prefer-ipv4, proxy)":                              {Host: "127.0.0.1:53", Proxy: true},
+		"udp://127.0.0.1:53 (prefer-ipv4, proxy group=dns)":                    {Host: "127.0.0.1:53", Proxy: true, Outbound: "group=dns"},
+		"udp://127.0.0.1:53 (ipv4-only, subnet 1.2.3.4, proxy hash=a, dnssec)": {Host: "127.0.0.1:53", Strategy: config.DNS_ipv4_only, Subnet: "1.2.3.4", Proxy: true, Outbound: "hash=a", Dnssec: true},
+	} {
+		if x := dnsCacheKey(dc); x != want {
+			t.Errorf("want %s, got %s", want, x)
+		}
+	}
+}
+
+func TestDNSSECConfig(t *testing.T) {
+	dc := &config.DNS{Host: "127.0.0.1:15358", Dnssec: true, TrustAnchors: []string{"invalid"}}
+	if _, ok := newDNSManager().get(dc, nil, "").(*dns.Group); ok {
+		t.Fatal("want the dnssec client")
+	}
+	if x := dnsCacheKey(dc); x != "udp://127.0.0.1:15358 (prefer-ipv4, dnssec)" {
+		t.Errorf("unexpected cache key: %s", x)
+	}
+	if !diffDNS(&config.DNS{Host: dc.Host}, dc) {
+		t.Error("want the dnssec change")
+	}
+}
+
+func TestDNSHosts(t *testing.T) {
+	d := newDNSManager()
+	d.hosts.Set(map[string]string{"staging.internal": "10.0.0.1"})
+
+	ips, err := d.get(&config.DNS{Host: "127.0.0.1:15359"}, nil, "").LookupIP("staging.internal")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("want the ip of the hosts, got %v %v", ips, err)
+	}
+}
+
+func TestPersistDNSCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := newDNSManager()
+	if err = d.Persist(dir, 0); err != nil {
+		t.Fatal(err)
+	}
+	dc := &config.DNS{Host: "127.0.0.1:15360"}
+	_, _ = d.cache(dc).Lookup("staging.internal", func(string) ([]net.IP, uint32, error) {
+		return []net.IP{net.IPv4(10, 0, 0, 1)}, 300, nil
+	})
+	if err = d.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// restart
+	d = newDNSManager()
+	if err = d.Persist(dir, 0); err != nil {
+		t.Fatal(err)
+	}
+	ips, err := d.cache(dc).Lookup("staging.internal", func(string) ([]net.IP, uint32, error) {
+		return nil, 0, errors.New("network is down")
+	})
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("want the saved answer, got %v %v", ips, err)
+	}
+}
+
+func TestDNSManagerPrune(t *testing.T) {
+	d := newDNSManager()
+	group := &config.DNS{
+		Host:           "127.0.0.1:15361",
+		Upstreams:      []*config.DNSUpstream{{Host: "127.0.0.1:15362"}},
+		Mode:           config.DNS_fallback,
+		FallbackFilter: []string{"10.0.0.0/8"},
+	}
+	local := &config.DNS{Host: "127.0.0.1:15363"}
+	d.get(group, nil, "")
+	d.get(local, nil, "")
+	if len(d.CacheStats()) != 2 || len(d.UpstreamStats()) != 2 || len(d.trusts) != 1 {
+		t.Fatalf("want 2 caches, 2 upstreams and 1 filter, got %v %v %d", d.CacheStats(), d.UpstreamStats(), len(d.trusts))
+	}
+
+	// the dns is changed, the local dns is kept
+	d.prune(&config.Setting{DNS: &config.DNS{Host: "127.0.0.1:15364"}, LocalDNS: local})
+	s := d.CacheStats()
+	if len(s) != 1 || s[0].Name != dnsCacheKey(local) {
+		t.Errorf("want the cache of the local dns only, got %v", s)
+	}
+	if len(d.UpstreamStats()) != 0 || len(d.trusts) != 0 {
+		t.Errorf("want the upstreams and filters dropped, got %v %d", d.UpstreamStats(), len(d.trusts))
+	}
+}
+
+func TestDNSCacheStale(t *testing.T) {
+	d := newDNSManager()
+	for _, v := range []struct {
+		stale int64
+		want  time.Duration
+	}{{0, dnsCacheStale}, {60, time.Minute}, {-1, 0}} {
+		dc := &config.DNS{Host: "127.0.0.1:15365", CacheStale: v.stale}
+		if x := d.cache(dc).Stale; x != v.want {
+			t.Errorf("cache_stale %d: want %v, got %v", v.stale, v.want, x)
+		}
+	}
+	if len(d.caches) != 3 {
+		t.Errorf("want the caches of the different stales apart, got %d", len(d.caches))
+	}
+}
+
+func TestSaveLiveDNSCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := newDNSManager()
+	if err = d.Persist(dir, 0); err != nil {
+		t.Fatal(err)
+	}
+	old, live := &config.DNS{Host: "127.0.0.1:15366"}, &config.DNS{Host: "127.0.0.1:15367"}
+	for _, dc := range []*config.DNS{old, live} {
+		_, _ = d.cache(dc).Lookup("staging.internal", func(string) ([]net.IP, uint32, error) {
+			return []net.IP{net.IPv4(10, 0, 0, 1)}, 300, nil
+		})
+	}
+	d.prune(&config.Setting{DNS: live})
+	if err = d.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "dns_cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s dnsCacheSnapshot
+	if err = json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Caches[dnsCacheKey(old)]; ok || len(s.Caches) != 1 {
+		t.Errorf("want the cache of the current dns only, got %v", s.Caches)
+	}
+}
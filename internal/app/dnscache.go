@@ -0,0 +1,137 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+)
+
+//dnsCacheStale the default of the cache_stale, the answers rarely change in a day,
+//so the lookups after waking up or switching the network are answered at once and corrected by the refreshing
+const dnsCacheStale = 24 * time.Hour
+
+//dnsStale the expired answers of the dns are served up to the cache_stale while refreshing in background
+func dnsStale(dc *config.DNS) time.Duration {
+	switch {
+	case dc.CacheStale < 0:
+		return 0
+	case dc.CacheStale == 0:
+		return dnsCacheStale
+	}
+	return time.Duration(dc.CacheStale) * time.Second
+}
+
+//dnsCacheSnapshot the dns caches saved on disk
+type dnsCacheSnapshot struct {
+	// Saved the unix time of saving, the ttl of the entries is remaining at that time
+	Saved int64 `json:"saved"`
+	// Caches key: the name of the cache, dnsCacheKey
+	Caches map[string][]dns.CacheEntry `json:"caches"`
+}
+
+//dnsCacheStore the file of the dns caches of the DNSManager
+type dnsCacheStore struct {
+	file  string
+	saved time.Time
+	// snapshots the loaded entries not restored yet, the caches are restored when they are created
+	snapshots map[string][]dns.CacheEntry
+	lock      sync.Mutex
+}
+
+//Persist load the dns caches saved in the dir, and save them every interval, 0 to save only by Save
+func (d *DNSManager) Persist(dir string, interval time.Duration) error {
+	file := filepath.Join(dir, "dns_cache.json")
+
+	d.store.lock.Lock()
+	d.store.file = file
+	d.store.lock.Unlock()
+
+	if interval > 0 {
+		go func() {
+			for range time.Tick(interval) {
+				if err := d.Save(); err != nil {
+					log.Printf("save dns cache failed: %v", err)
+				}
+			}
+		}()
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read dns cache failed: %v", err)
+	}
+	var s dnsCacheSnapshot
+	if err = json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parse dns cache failed: %v", err)
+	}
+
+	d.store.lock.Lock()
+	d.store.saved, d.store.snapshots = time.Unix(s.Saved, 0), s.Caches
+	d.store.lock.Unlock()
+
+	for key := range s.Caches {
+		d.lock.Lock()
+		c, ok := d.caches[key]
+		d.lock.Unlock()
+		if ok {
+			d.restore(key, c)
+		}
+	}
+	return nil
+}
+
+//restore restore the loaded entries of the cache once
+func (d *DNSManager) restore(key string, c *dns.Cache) {
+	d.store.lock.Lock()
+	entries, ok := d.store.snapshots[key]
+	saved := d.store.saved
+	delete(d.store.snapshots, key)
+	d.store.lock.Unlock()
+	if ok {
+		c.Restore(saved, entries)
+	}
+}
+
+//Save save the dns caches to the dir of Persist, nothing if Persist isn't called,
+//only the caches of the current config are saved, the others are dropped by the prune
+func (d *DNSManager) Save() error {
+	d.store.lock.Lock()
+	defer d.store.lock.Unlock()
+	if d.store.file == "" {
+		return nil
+	}
+
+	s := dnsCacheSnapshot{Saved: time.Now().Unix(), Caches: make(map[string][]dns.CacheEntry)}
+	d.lock.Lock()
+	for k, v := range d.caches {
+		if entries := v.Entries(); len(entries) != 0 {
+			s.Caches[k] = entries
+		}
+	}
+	d.lock.Unlock()
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal dns cache failed: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(d.store.file), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("make dir all failed: %v", err)
+	}
+	tmp := d.store.file + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, os.ModePerm); err != nil {
+		return fmt.Errorf("write dns cache failed: %v", err)
+	}
+	return os.Rename(tmp, d.store.file)
+}
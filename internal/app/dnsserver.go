@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//DNSServer the dns server inbound, the upstream is chosen by the bypass rules:
+//the domains of direct rules are resolved by the local dns, block domains get NXDOMAIN or 0.0.0.0,
+//others are resolved by the dns, through the proxy if the dns proxy is enabled
+type DNSServer struct {
+	server proxy.Server
+	dns    *DNSManager
+	bypass *BypassManager
+	proxy  proxy.Proxy
+
+	local       dns.DNS
+	remote      dns.DNS
+	blockZeroIP bool
+	lock        sync.RWMutex
+}
+
+//NewDNSServer dm: the dns clients, b: the bypass rules, nil to resolve all domains by the dns, p: the proxy of the dns
+func NewDNSServer(conf *config.Config, dm *DNSManager, b *BypassManager, p proxy.Proxy) (*DNSServer, error) {
+	d := &DNSServer{dns: dm, bypass: b, proxy: p}
+
+	var err error
+	d.server, err = dns.NewServer("", d.handle)
+	if err != nil {
+		return nil, fmt.Errorf("create dns server failed: %v", err)
+	}
+
+	err = conf.Exec(func(s *config.Setting) error {
+		d.set(s)
+		return d.server.SetServer(s.Proxy.GetDNS())
+	})
+	if err != nil {
+		return d, err
+	}
+
+	conf.AddObserver(func(current, old *config.Setting) {
+		if diffDNS(current.DNS, old.DNS) || diffDNS(current.LocalDNS, old.LocalDNS) ||
+			current.Proxy.GetDnsBlockZeroIp() != old.Proxy.GetDnsBlockZeroIp() {
+			d.set(current)
+		}
+	})
+
+	conf.AddObserver(func(current, _ *config.Setting) {
+		if err := d.server.SetServer(current.Proxy.GetDNS()); err != nil {
+			log.Printf("set dns server failed: %v", err)
+		}
+	})
+	return d, nil
+}
+
+func (d *DNSServer) set(s *config.Setting) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.local = d.dns.get(s.LocalDNS, d.proxy, s.Bypass.GeoipFile)
+	d.remote = d.dns.get(s.DNS, d.proxy, s.Bypass.GeoipFile)
+	d.blockZeroIP = s.Proxy.GetDnsBlockZeroIp()
+}
+
+func (d *DNSServer) handle(req []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	h, err := p.Start(req)
+	if err != nil {
+		return nil, fmt.Errorf("parse dns header failed: %v", err)
+	}
+	q, err := p.Question()
+	if err != nil {
+		return dnsReply(h, nil, dnsmessage.RCodeFormatError, nil)
+	}
+
+	d.lock.RLock()
+	local, remote, blockZeroIP := d.local, d.remote, d.blockZeroIP
+	d.lock.RUnlock()
+
+	domain := strings.TrimSuffix(q.Name.String(), ".")
+	mode := OTHERS
+	if d.bypass != nil && domain != "" {
+		// the domain rules only, the network, port and ip rules are for the connections, not the queries
+		mode = d.bypass.DomainRule("dns", domain).Mode
+	}
+
+	if mode == BLOCK {
+		if !blockZeroIP || q.Class != dnsmessage.ClassINET {
+			return dnsReply(h, &q, dnsmessage.RCodeNameError, nil)
+		}
+		switch q.Type {
+		case dnsmessage.TypeA:
+			return dnsReply(h, &q, dnsmessage.RCodeSuccess, &dnsmessage.AResource{})
+		case dnsmessage.TypeAAAA:
+			return dnsReply(h, &q, dnsmessage.RCodeSuccess, &dnsmessage.AAAAResource{})
+		}
+		return dnsReply(h, &q, dnsmessage.RCodeSuccess, nil)
+	}
+
+	upstream := remote
+	if mode == DIRECT {
+		upstream = local
+	}
+	resp, err := upstream.Do(req)
+	if err != nil {
+		// reply SERVFAIL, or the client waits until its own timeout
+		log.Printf("resolve %s failed: %v", domain, err)
+		return dnsReply(h, &q, dnsmessage.RCodeServerFailure, nil)
+	}
+	return resp, nil
+}
+
+//dnsReply build the response of the request, answer: nil, A or AAAA resource
+func dnsReply(req dnsmessage.Header, q *dnsmessage.Question, rcode dnsmessage.RCode, answer dnsmessage.ResourceBody) ([]byte, error) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 req.ID,
+			Response:           true,
+			OpCode:             req.OpCode,
+			RecursionDesired:   req.RecursionDesired,
+			RecursionAvailable: true,
+			RCode:              rcode,
+		},
+	}
+	if q != nil {
+		msg.Questions = []dnsmessage.Question{*q}
+	}
+	if answer != nil && q != nil {
+		msg.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60},
+			Body:   answer,
+		}}
+	}
+	return msg.Pack()
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//mockDNS answer the TXT record of the name, or fail if err isn't nil
+type mockDNS struct {
+	dns.DNS
+	name string
+	err  error
+}
+
+func (m *mockDNS) Do(req []byte) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, err
+	}
+	msg.Response = true
+	msg.Answers = []dnsmessage.Resource{{
+		Header: dnsmessage.ResourceHeader{Name: msg.Questions[0].Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET},
+		Body:   &dnsmessage.TXTResource{TXT: []string{m.name}},
+	}}
+	return msg.Pack()
+}
+
+func TestDNSServer(t *testing.T) {
+	m := &BypassManager{
+		bypass: true,
+		// the conn rules like network:udp don't apply to the queries
+		explain: func(network, host string) (Rule, bool, net.IP) {
+			return Rule{Target: Target{Mode: BLOCK}}, false, nil
+		},
+		domain: func(domain string) Rule {
+			switch domain {
+			case "direct.com":
+				return Rule{Target: Target{Mode: DIRECT}}
+			case "block.com":
+				return Rule{Target: Target{Mode: BLOCK}}
+			}
+			return Rule{}
+		},
+	}
+	d := &DNSServer{bypass: m, local: &mockDNS{name: "local"}, remote: &mockDNS{name: "remote"}}
+
+	query := func(name string, typ dnsmessage.Type) dnsmessage.Message {
+		msg := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: 42, RecursionDesired: true},
+			Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName(name), Type: typ, Class: dnsmessage.ClassINET}},
+		}
+		req, err := msg.Pack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := d.handle(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp dnsmessage.Message
+		if err = resp.Unpack(b); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	upstream := func(resp dnsmessage.Message) string {
+		if len(resp.Answers) != 1 {
+			return ""
+		}
+		if x, ok := resp.Answers[0].Body.(*dnsmessage.TXTResource); ok {
+			return x.TXT[0]
+		}
+		return ""
+	}
+
+	if x := upstream(query("direct.com.", dnsmessage.TypeA)); x != "local" {
+		t.Errorf("want local, got %s", x)
+	}
+	if x := upstream(query("www.google.com.", dnsmessage.TypeAAAA)); x != "remote" {
+		t.Errorf("want remote, got %s", x)
+	}
+
+	if resp := query("block.com.", dnsmessage.TypeA); resp.RCode != dnsmessage.RCodeNameError || resp.ID != 42 {
+		t.Errorf("want NXDOMAIN, got %v", resp)
+	}
+
+	d.blockZeroIP = true
+	resp := query("block.com.", dnsmessage.TypeA)
+	if len(resp.Answers) != 1 || resp.Answers[0].Body.(*dnsmessage.AResource).A != [4]byte{} {
+		t.Errorf("want 0.0.0.0, got %v", resp)
+	}
+
+	// the failure of the upstream is answered by SERVFAIL
+	d.remote = &mockDNS{err: errors.New("mock timeout")}
+	resp = query("www.google.com.", dnsmessage.TypeA)
+	if resp.RCode != dnsmessage.RCodeServerFailure || resp.ID != 42 || len(resp.Questions) != 1 {
+		t.Errorf("want SERVFAIL, got %v", resp)
+	}
+}
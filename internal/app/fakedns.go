@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/fakeip"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"google.golang.org/protobuf/proto"
+)
+
+//fakeIPTTL the domains not used in an hour are removed from the fake ip table
+const fakeIPTTL = time.Hour
+
+//FakeDNS the fake ip dns server, the fake ips are mapped back to the domains by the redir and tproxy
+type FakeDNS struct {
+	dns      *DNSManager
+	server   proxy.Server
+	resolver atomic.Value // *fakeip.Resolver
+}
+
+//NewFakeDNS d: the dns clients, the upstream of the fake dns is the local dns
+func NewFakeDNS(conf *config.Config, d *DNSManager) (*FakeDNS, error) {
+	f := &FakeDNS{dns: d}
+
+	var err error
+	f.server, err = proxy.NewUDPServer("", proxy.UDPWithHandle(func(b []byte, _ proxy.Proxy) ([]byte, error) {
+		r, ok := f.resolver.Load().(*fakeip.Resolver)
+		if !ok {
+			return nil, fmt.Errorf("fake dns is not initialized")
+		}
+		return r.Handle(b)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("create fake dns server failed: %v", err)
+	}
+
+	err = conf.Exec(func(s *config.Setting) error {
+		return f.set(s, nil)
+	})
+	if err != nil {
+		return f, err
+	}
+
+	conf.AddObserver(func(current, old *config.Setting) {
+		if !proto.Equal(current.FakeDNS, old.FakeDNS) || diffDNS(current.LocalDNS, old.LocalDNS) ||
+			current.Bypass.GeositeFile != old.Bypass.GeositeFile {
+			if err := f.set(current, old); err != nil {
+				log.Printf("set fake dns failed: %v", err)
+			}
+		}
+	})
+	return f, nil
+}
+
+func (f *FakeDNS) set(s, old *config.Setting) error {
+	c := s.GetFakeDNS()
+	if !c.GetEnabled() {
+		fakeip.SetPool(nil)
+		return f.server.SetServer("")
+	}
+
+	r := &fakeip.Resolver{Upstream: f.dns.get(s.LocalDNS, nil, s.Bypass.GeoipFile)}
+
+	// keep the table if the pool is not changed
+	if x, ok := f.resolver.Load().(*fakeip.Resolver); ok && old.GetFakeDNS().GetEnabled() &&
+		poolCIDR(old.GetFakeDNS().GetPool()) == poolCIDR(c.Pool) {
+		r.Pool = x.Pool
+	} else {
+		p, err := fakeip.NewPool(poolCIDR(c.Pool), fakeIPTTL)
+		if err != nil {
+			return err
+		}
+		r.Pool = p
+	}
+
+	r.Exclude = newFakeIPExclude(c.Exclude, s.Bypass.GeositeFile)
+
+	f.resolver.Store(r)
+	fakeip.SetPool(r.Pool)
+	return f.server.SetServer(c.Host)
+}
+
+func poolCIDR(s string) string {
+	if s == "" {
+		return fakeip.DefaultPool
+	}
+	return s
+}
+
+//newFakeIPExclude the exclude rules are same as the domain of bypass rules
+func newFakeIPExclude(rules []string, geositeFile string) func(string) bool {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	m := mapper.NewMapper(nil)
+	for _, r := range rules {
+		if strings.HasPrefix(strings.ToLower(r), "geosite:") && geositeFile != "" {
+			g, err := mapper.NewGeoSite(geositeFile)
+			if err != nil {
+				log.Printf("load geosite for fake dns exclude failed: %v", err)
+			}
+			m.SetGeoSite(g)
+			break
+		}
+	}
+	for _, r := range rules {
+		if r = strings.TrimSpace(r); r != "" {
+			m.Insert(r, true)
+		}
+	}
+	m.SetGeoSite(nil)
+
+	return func(domain string) bool { return m.Search(domain) != nil }
+}
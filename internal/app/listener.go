@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"net"
 	"runtime"
 
 	"github.com/Asutorufa/yuhaiin/internal/config"
@@ -55,10 +56,10 @@ func NewListener(c *config.Config, pro proxy.Proxy) (l *Listener, err error) {
 		}
 	})
 
-	l.socks5.SetProxy(l)
-	l.http.SetProxy(l)
+	l.socks5.SetProxy(&inbound{"socks5", l})
+	l.http.SetProxy(&inbound{"http", l})
 	if runtime.GOOS != "windows" {
-		l.redir.SetProxy(l)
+		l.redir.SetProxy(&inbound{"redir", l})
 	}
 	return l, nil
 }
@@ -71,3 +72,43 @@ func (l *Listener) SetProxy(p proxy.Proxy) {
 	}
 
 }
+
+func (l *Listener) ConnWithMetadata(host string, m *proxy.Metadata) (net.Conn, error) {
+	return proxy.ConnWithMetadata(l.Proxy, host, m)
+}
+
+func (l *Listener) PacketConnWithMetadata(host string, m *proxy.Metadata) (net.PacketConn, error) {
+	return proxy.PacketConnWithMetadata(l.Proxy, host, m)
+}
+
+var _ proxy.MetadataProxy = (*inbound)(nil)
+
+//inbound set the name of the listener to the metadata
+type inbound struct {
+	name  string
+	proxy proxy.Proxy
+}
+
+func (i *inbound) Conn(host string) (net.Conn, error) {
+	return i.ConnWithMetadata(host, &proxy.Metadata{})
+}
+
+func (i *inbound) PacketConn(host string) (net.PacketConn, error) {
+	return i.PacketConnWithMetadata(host, &proxy.Metadata{})
+}
+
+func (i *inbound) ConnWithMetadata(host string, m *proxy.Metadata) (net.Conn, error) {
+	return proxy.ConnWithMetadata(i.proxy, host, i.metadata(m))
+}
+
+func (i *inbound) PacketConnWithMetadata(host string, m *proxy.Metadata) (net.PacketConn, error) {
+	return proxy.PacketConnWithMetadata(i.proxy, host, i.metadata(m))
+}
+
+func (i *inbound) metadata(m *proxy.Metadata) *proxy.Metadata {
+	x := &proxy.Metadata{Inbound: i.name}
+	if m != nil {
+		x.Source = m.Source
+	}
+	return x
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"google.golang.org/protobuf/proto"
+)
+
+//policy the routing policy of an inbound listener
+type policy struct {
+	// shunt the bypass profile, nil for the default bypass
+	shunt *Shunt
+	// target the fixed outbound, override the shunt
+	target *Target
+}
+
+//newPolicies create the policies of listeners, key is the name of listener, eg: socks5, http, redir
+func newPolicies(s *config.Setting, d *DNSManager, px proxy.Proxy) map[string]*policy {
+	ps := make(map[string]*policy, len(s.Proxy.GetPolicies()))
+	for name, c := range s.Proxy.GetPolicies() {
+		p, err := newPolicy(s, c, d, px)
+		if err != nil {
+			log.Printf("create policy of %s failed: %v, use the default bypass\n", name, err)
+			continue
+		}
+		if p != nil {
+			ps[name] = p
+		}
+	}
+	return ps
+}
+
+func newPolicy(s *config.Setting, c *config.InboundPolicy, d *DNSManager, px proxy.Proxy) (*policy, error) {
+	if c.Outbound != "" {
+		t, err := ParseTarget(c.Outbound)
+		if err != nil {
+			return nil, fmt.Errorf("parse outbound failed: %v", err)
+		}
+		return &policy{target: &t}, nil
+	}
+
+	if c.BypassFile == "" {
+		return nil, nil
+	}
+
+	shunt, err := newProfileShunt(s, c.BypassFile, d, px)
+	if err != nil {
+		return nil, fmt.Errorf("create shunt of %s failed: %v", c.BypassFile, err)
+	}
+	return &policy{shunt: shunt}, nil
+}
+
+//diffPolicies the profile shunts also depend on the bypass and dns setting
+func diffPolicies(old, new *config.Setting) bool {
+	if len(old.Proxy.GetPolicies()) != len(new.Proxy.GetPolicies()) {
+		return true
+	}
+	for k, v := range old.Proxy.GetPolicies() {
+		if !proto.Equal(v, new.Proxy.GetPolicies()[k]) {
+			return true
+		}
+	}
+
+	if len(new.Proxy.GetPolicies()) == 0 {
+		return false
+	}
+	return !proto.Equal(old.Bypass, new.Bypass) || diffDNS(old.DNS, new.DNS)
+}
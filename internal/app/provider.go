@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+)
+
+const defaultProviderInterval = 24 * time.Hour
+
+//providerRetry the first retry after a failed download, it doubles after every failure up to the interval
+const providerRetry = 30 * time.Second
+
+//provider a remote rule set, cached on disk and refreshed periodically
+type provider struct {
+	url      string
+	file     string
+	interval time.Duration
+	retry    time.Duration
+	client   *http.Client
+
+	// update call after the rules are downloaded successfully
+	update func()
+	close  chan struct{}
+}
+
+func newProvider(dir string, c *config.RuleProvider, p proxy.Proxy, update func()) *provider {
+	name := c.Name
+	if name == "" {
+		z := sha256.Sum256([]byte(c.Url))
+		name = hex.EncodeToString(z[:8])
+	}
+
+	interval := time.Duration(c.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultProviderInterval
+	}
+
+	retry := providerRetry
+	if retry > interval {
+		retry = interval
+	}
+
+	dialer := &net.Dialer{Timeout: 15 * time.Second}
+	dial := dialer.DialContext
+	if c.Proxy && p != nil {
+		dial = func(_ context.Context, _, addr string) (net.Conn, error) { return p.Conn(addr) }
+	}
+
+	return &provider{
+		url:      c.Url,
+		file:     filepath.Join(dir, "rules", url.PathEscape(name)+".conf"),
+		interval: interval,
+		retry:    retry,
+		client: &http.Client{
+			Timeout:   time.Minute,
+			Transport: &http.Transport{DialContext: dial},
+		},
+		update: update,
+		close:  make(chan struct{}),
+	}
+}
+
+func (r *provider) start() {
+	go func() {
+		next := time.Duration(0)
+		if s, err := os.Stat(r.file); err == nil {
+			next = r.interval - time.Since(s.ModTime())
+		}
+		retry := r.retry
+
+		for {
+			if next > 0 {
+				select {
+				case <-r.close:
+					return
+				case <-time.After(next):
+				}
+			}
+
+			if err := r.fetch(); err != nil {
+				log.Printf("refresh rule provider %s failed: %v, keep the last rules, retry after %v\n", r.url, err, retry)
+				// retry soon, the provider without the cache is empty until the download succeeds
+				next, retry = retry, retry*2
+				if retry > r.interval {
+					retry = r.interval
+				}
+				continue
+			}
+			next, retry = r.interval, r.retry
+			r.update()
+		}
+	}()
+}
+
+func (r *provider) stop() { close(r.close) }
+
+//fetch download the rules to a temporary file, then rename it to the cache file,
+//so the cache file always keep the last good rules
+func (r *provider) fetch() error {
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %v", err)
+	}
+	req.Header.Set("User-Agent", "yuhaiin")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("get rules failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("get rules failed: %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read rules failed: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.file), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("make dir all failed: %v", err)
+	}
+
+	tmp := r.file + ".tmp"
+	err = ioutil.WriteFile(tmp, data, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("write rules failed: %v", err)
+	}
+
+	return os.Rename(tmp, r.file)
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+)
+
+func TestProvider(t *testing.T) {
+	ok := true
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("*.example.com DIRECT\nwww.example.net BLOCK\n"))
+	}))
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := newProvider(dir, &config.RuleProvider{Name: "test", Url: s.URL}, nil, func() {})
+	if err = p.fetch(); err != nil {
+		t.Fatal(err)
+	}
+
+	ok = false
+	if err = p.fetch(); err == nil {
+		t.Error("want error when server failed")
+	}
+
+	m := mapper.NewMapper(nil)
+	if err = insertRules(m, p.file); err != nil {
+		t.Fatal(err)
+	}
+
+	if x, _ := m.Search("www.example.com").(Rule); x.Mode != DIRECT {
+		t.Errorf("www.example.com want direct, got %v", x)
+	}
+	if x, _ := m.Search("www.example.net").(Rule); x.Mode != BLOCK {
+		t.Errorf("www.example.net want block, got %v", x)
+	}
+}
+
+func TestProviderRetry(t *testing.T) {
+	var fails int32 = 2
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fails, -1) >= 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("*.example.com DIRECT\n"))
+	}))
+	defer s.Close()
+
+	updated := make(chan struct{}, 1)
+	p := newProvider(t.TempDir(), &config.RuleProvider{Name: "test", Url: s.URL}, nil, func() { updated <- struct{}{} })
+	p.retry = 10 * time.Millisecond
+	p.start()
+	defer p.stop()
+
+	// the failed downloads are retried before the interval
+	select {
+	case <-updated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("want the rules after the retries")
+	}
+	if x := atomic.LoadInt32(&fails); x >= 0 {
+		t.Errorf("want the failures before the rules, got %d left", x)
+	}
+}
@@ -13,13 +13,13 @@ import (
 	"os"
 	"path"
 	"regexp"
-	"strings"
 	"sync"
 	"unsafe"
 
 	"github.com/Asutorufa/yuhaiin/internal/config"
-	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
 	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"google.golang.org/protobuf/proto"
 )
 
 //go:embed yuhaiin.conf
@@ -40,20 +40,35 @@ func saveBypassData(filePath string) (err error) {
 }
 
 type Shunt struct {
-	file   string
-	mapper *mapper.Mapper
+	file        string
+	geoipFile   string
+	geositeFile string
+	ordered     bool
+	dir         string
+	mapper      *mapper.Mapper
+	lookup      func(string) ([]net.IP, error)
+	proxy       proxy.Proxy
+	providers   []*provider
 
-	fileLock sync.RWMutex
+	fileLock     sync.RWMutex
+	mapperLock   sync.RWMutex
+	providerLock sync.Mutex
+	refreshLock  sync.Mutex
 }
 
-//NewShunt file: bypass file; lookup: domain resolver, can be nil
-func NewShunt(conf *config.Config) (*Shunt, error) {
-	s := &Shunt{}
+//NewShunt create shunt from the bypass setting, d: the dns of the ip rules, p: proxy for downloading rule providers, can be nil
+func NewShunt(conf *config.Config, d *DNSManager, p proxy.Proxy) (*Shunt, error) {
+	s := &Shunt{dir: conf.Dir(), proxy: p}
 
 	err := conf.Exec(
 		func(ss *config.Setting) error {
 			s.file = ss.Bypass.BypassFile
-			s.mapper = mapper.NewMapper(getDNS(ss.DNS).LookupIP)
+			s.geoipFile = ss.Bypass.GeoipFile
+			s.geositeFile = ss.Bypass.GeositeFile
+			s.ordered = ss.Bypass.Ordered
+			s.lookup = d.get(ss.DNS, s.proxy, ss.Bypass.GeoipFile).LookupIP
+			s.mapper = mapper.NewMapper(s.lookup)
+			s.setProviders(ss.Bypass.Providers)
 			err := s.RefreshMapping()
 			if err != nil {
 				return fmt.Errorf("refresh mapping failed: %v", err)
@@ -73,9 +88,39 @@ func NewShunt(conf *config.Config) (*Shunt, error) {
 		}
 	})
 
+	conf.AddObserver(func(current, old *config.Setting) {
+		if current.Bypass.GeoipFile != old.Bypass.GeoipFile ||
+			current.Bypass.GeositeFile != old.Bypass.GeositeFile ||
+			current.Bypass.Ordered != old.Bypass.Ordered {
+			s.fileLock.Lock()
+			s.geoipFile = current.Bypass.GeoipFile
+			s.geositeFile = current.Bypass.GeositeFile
+			s.ordered = current.Bypass.Ordered
+			s.fileLock.Unlock()
+
+			err := s.RefreshMapping()
+			if err != nil {
+				log.Printf("shunt refresh mapping failed: %v", err)
+			}
+		}
+	})
+
+	conf.AddObserver(func(current, old *config.Setting) {
+		if diffProviders(current.Bypass.Providers, old.Bypass.Providers) {
+			s.setProviders(current.Bypass.Providers)
+			err := s.RefreshMapping()
+			if err != nil {
+				log.Printf("shunt refresh mapping failed: %v", err)
+			}
+		}
+	})
+
 	conf.AddObserver(func(current, old *config.Setting) {
 		if diffDNS(current.DNS, old.DNS) {
-			s.mapper.SetLookup(getDNS(current.DNS).LookupIP)
+			s.mapperLock.Lock()
+			s.lookup = d.get(current.DNS, s.proxy, current.Bypass.GeoipFile).LookupIP
+			s.mapper.SetLookup(s.lookup)
+			s.mapperLock.Unlock()
 		}
 	})
 
@@ -86,7 +131,51 @@ func NewShunt(conf *config.Config) (*Shunt, error) {
 	return s, nil
 }
 
+//newProfileShunt create shunt from a bypass profile file, without the rule providers,
+//it don't follow the changes of setting, recreate it when the setting changed
+func newProfileShunt(ss *config.Setting, file string, d *DNSManager, p proxy.Proxy) (*Shunt, error) {
+	s := &Shunt{
+		file:        file,
+		geoipFile:   ss.Bypass.GeoipFile,
+		geositeFile: ss.Bypass.GeositeFile,
+		ordered:     ss.Bypass.Ordered,
+		lookup:      d.get(ss.DNS, p, ss.Bypass.GeoipFile).LookupIP,
+	}
+	s.mapper = mapper.NewMapper(s.lookup)
+	return s, s.RefreshMapping()
+}
+
+//setProviders stop the old rule providers and start the new
+func (s *Shunt) setProviders(ps []*config.RuleProvider) {
+	s.providerLock.Lock()
+	defer s.providerLock.Unlock()
+
+	for i := range s.providers {
+		s.providers[i].stop()
+	}
+
+	s.providers = make([]*provider, 0, len(ps))
+	for i := range ps {
+		if ps[i].Url == "" {
+			continue
+		}
+		r := newProvider(s.dir, ps[i], s.proxy, func() {
+			err := s.RefreshMapping()
+			if err != nil {
+				log.Printf("shunt refresh mapping failed: %v", err)
+			}
+		})
+		r.start()
+		s.providers = append(s.providers, r)
+	}
+}
+
+//RefreshMapping build a new mapper from the bypass file and the cache files of rule providers,
+//then replace the current mapper, the current mapper is kept if the bypass file can't be read
 func (s *Shunt) RefreshMapping() error {
+	s.refreshLock.Lock()
+	defer s.refreshLock.Unlock()
+
 	s.fileLock.RLock()
 	defer s.fileLock.RUnlock()
 
@@ -98,17 +187,73 @@ func (s *Shunt) RefreshMapping() error {
 		return err
 	}
 
-	f, err := os.Open(s.file)
+	s.mapperLock.RLock()
+	var m *mapper.Mapper
+	if s.ordered {
+		m = mapper.NewOrderedMapper(s.lookup)
+	} else {
+		m = mapper.NewMapper(s.lookup)
+	}
+	s.mapperLock.RUnlock()
+
+	if s.geositeFile != "" {
+		g, err := mapper.NewGeoSite(s.geositeFile)
+		if err != nil {
+			log.Printf("load geosite file failed: %v, ignore geosite rules", err)
+		}
+		m.SetGeoSite(g)
+		defer m.SetGeoSite(nil) // release the geosite lists after all rules are inserted
+	}
+
+	// the first matched rule wins in the ordered mode, otherwise the later rule overrides the former,
+	// so the bypass file is inserted first in the ordered mode and at last in the other mode
+	if s.ordered {
+		if err = insertRules(m, s.file); err != nil {
+			return err
+		}
+	}
+
+	s.providerLock.Lock()
+	for i := range s.providers {
+		err = insertRules(m, s.providers[i].file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("insert rules of %s failed: %v", s.providers[i].url, err)
+		}
+	}
+	s.providerLock.Unlock()
+
+	if !s.ordered {
+		if err = insertRules(m, s.file); err != nil {
+			return err
+		}
+	}
+
+	if countries := m.GeoIPCountries(); len(countries) != 0 {
+		if s.geoipFile == "" {
+			log.Printf("geoip file is not set, ignore geoip rules: %v", countries)
+		} else if g, err := mapper.NewGeoIP(s.geoipFile, countries...); err != nil {
+			log.Printf("load geoip file failed: %v, ignore geoip rules", err)
+		} else {
+			m.SetGeoIP(g)
+		}
+	}
+
+	s.mapperLock.Lock()
+	s.mapper = m
+	s.mapperLock.Unlock()
+	return nil
+}
+
+func insertRules(m *mapper.Mapper, file string) error {
+	f, err := os.Open(file)
 	if err != nil {
 		return fmt.Errorf("open bypass file failed: %w", err)
 	}
 	defer f.Close()
 
-	s.mapper.Clear()
-
 	re, _ := regexp.Compile("^([^ ]+) +([^ ]+) *$") // already test that is right regular expression, so don't need to check error
 	br := bufio.NewReader(f)
-	for {
+	for line := 1; ; line++ {
 		a, _, c := br.ReadLine()
 		if c == io.EOF {
 			break
@@ -120,57 +265,70 @@ func (s *Shunt) RefreshMapping() error {
 		if len(result) != 3 {
 			continue
 		}
-		mode := Mode[strings.ToLower(*(*string)(unsafe.Pointer(&result[2])))]
-		if mode == OTHERS {
+		target, err := ParseTarget(*(*string)(unsafe.Pointer(&result[2])))
+		if err != nil {
+			log.Printf("parse rule [%s] failed: %v", a, err)
 			continue
 		}
-		s.mapper.Insert(string(result[1]), mode)
+		rule := string(result[1])
+		m.Insert(rule, Rule{Target: target, Rule: rule, File: file, Line: line})
 	}
 	return nil
 }
 
 func (s *Shunt) SetFile(f string) error {
+	s.fileLock.Lock()
 	if s.file == f {
+		s.fileLock.Unlock()
 		return nil
 	}
-	s.fileLock.Lock()
 	s.file = f
 	s.fileLock.Unlock()
 
 	return s.RefreshMapping()
 }
 
-func (s *Shunt) Get(domain string) MODE {
-	x, _ := s.mapper.Search(domain).(MODE)
+//Get get the matched rule of the address, network: tcp or udp, addr: host:port,
+//owner: the owner of the inbound connection for the process rules, can be nil,
+//the Mode is OTHERS if no rule matched
+func (s *Shunt) Get(network, addr string, owner mapper.Owner) Rule {
+	s.mapperLock.RLock()
+	m := s.mapper
+	s.mapperLock.RUnlock()
+
+	x, _ := m.SearchConn(network, addr, owner).(Rule)
 	return x
 }
 
-func diffDNS(old, new *config.DNS) bool {
-	if old.Host != new.Host {
-		return true
-	}
-	if old.DOH != new.DOH {
-		return true
-	}
-	if old.Subnet != new.Subnet {
-		return true
-	}
-	return false
+//SearchDomain get the matched rule of the domain by the domain rules only, the Mode is OTHERS if no rule matched
+func (s *Shunt) SearchDomain(domain string) Rule {
+	s.mapperLock.RLock()
+	m := s.mapper
+	s.mapperLock.RUnlock()
+
+	x, _ := m.SearchDomain(domain).(Rule)
+	return x
 }
 
-func getDNS(dc *config.DNS) dns.DNS {
-	_, subnet, err := net.ParseCIDR(dc.Subnet)
-	if err != nil {
-		if net.ParseIP(dc.Subnet).To4() != nil {
-			_, subnet, _ = net.ParseCIDR(dc.Subnet + "/32")
-		}
+//Explain get the matched rule of the address, and whether the lru cache answered, the ip used for cidr rules
+func (s *Shunt) Explain(network, addr string) (Rule, bool, net.IP) {
+	s.mapperLock.RLock()
+	m := s.mapper
+	s.mapperLock.RUnlock()
 
-		if net.ParseIP(dc.Subnet).To16() != nil {
-			_, subnet, _ = net.ParseCIDR(dc.Subnet + "/128")
-		}
+	r := m.ExplainAddr(network, addr)
+	x, _ := r.Mark.(Rule)
+	return x, r.Cache, r.IP
+}
+
+func diffProviders(old, new []*config.RuleProvider) bool {
+	if len(old) != len(new) {
+		return true
 	}
-	if dc.DOH {
-		return dns.NewDoH(dc.Host, subnet, nil)
+	for i := range old {
+		if !proto.Equal(old[i], new[i]) {
+			return true
+		}
 	}
-	return dns.NewDNS(dc.Host, subnet, nil)
+	return false
 }
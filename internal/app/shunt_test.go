@@ -1,21 +1,24 @@
 package app
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/Asutorufa/yuhaiin/internal/config"
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper"
 )
 
 func TestShunt(t *testing.T) {
-	x, err := NewShunt(&config.Config{})
+	x, err := NewShunt(&config.Config{}, newDNSManager(), nil)
 	if err != nil {
 		t.Error(err)
 		t.FailNow()
 	}
 
-	t.Log(x.Get("sp0.baidu.com"))
-	t.Log(x.Get("www.baidu.com"))
-	t.Log(x.Get("www.google.com"))
+	t.Log(x.Get("tcp", "sp0.baidu.com:443", nil))
+	t.Log(x.Get("tcp", "www.baidu.com:443", nil))
+	t.Log(x.Get("tcp", "www.google.com:443", nil))
 }
 
 func TestMode(t *testing.T) {
@@ -28,3 +31,38 @@ func TestMode(t *testing.T) {
 		t.Log("OK", v)
 	}
 }
+
+func TestOrderedRules(t *testing.T) {
+	f, err := ioutil.TempFile("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString("# comment\nwww.example.com block\n*.example.com direct\nkeyword:example proxy\n10.0.0.0/8 direct\n")
+	f.Close()
+
+	for _, v := range []struct {
+		m    *mapper.Mapper
+		want string
+		line int
+	}{
+		{mapper.NewMapper(nil), "www.example.com", 2},
+		{mapper.NewOrderedMapper(nil), "www.example.com", 2},
+	} {
+		if err = insertRules(v.m, f.Name()); err != nil {
+			t.Fatal(err)
+		}
+		x, _ := v.m.Search("www.example.com").(Rule)
+		if x.Mode != BLOCK || x.Rule != v.want || x.Line != v.line || x.File != f.Name() {
+			t.Errorf("want block from line %d, got %v", v.line, x)
+		}
+	}
+
+	m := mapper.NewOrderedMapper(nil)
+	_ = insertRules(m, f.Name())
+	for k, v := range map[string]int{"a.example.com": 3, "example.org": 4, "10.1.1.1": 5} {
+		if x, _ := m.Search(k).(Rule); x.Line != v {
+			t.Errorf("%s want line %d, got %v", k, v, x)
+		}
+	}
+}
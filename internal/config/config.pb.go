@@ -26,6 +26,171 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// the address family of the resolved ips, the strategy of local_dns is also used to resolve the node servers
+type DNS_Strategy int32
+
+const (
+	DNS_prefer_ipv4 DNS_Strategy = 0
+	DNS_ipv4_only   DNS_Strategy = 1
+	DNS_ipv6_only   DNS_Strategy = 2
+	DNS_prefer_ipv6 DNS_Strategy = 3
+)
+
+// Enum value maps for DNS_Strategy.
+var (
+	DNS_Strategy_name = map[int32]string{
+		0: "prefer_ipv4",
+		1: "ipv4_only",
+		2: "ipv6_only",
+		3: "prefer_ipv6",
+	}
+	DNS_Strategy_value = map[string]int32{
+		"prefer_ipv4": 0,
+		"ipv4_only":   1,
+		"ipv6_only":   2,
+		"prefer_ipv6": 3,
+	}
+)
+
+func (x DNS_Strategy) Enum() *DNS_Strategy {
+	p := new(DNS_Strategy)
+	*p = x
+	return p
+}
+
+func (x DNS_Strategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DNS_Strategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_config_config_proto_enumTypes[0].Descriptor()
+}
+
+func (DNS_Strategy) Type() protoreflect.EnumType {
+	return &file_internal_config_config_proto_enumTypes[0]
+}
+
+func (x DNS_Strategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DNS_Strategy.Descriptor instead.
+func (DNS_Strategy) EnumDescriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{4, 0}
+}
+
+// the transport of the dns, host format:
+// udp, tcp: 8.8.8.8, 8.8.8.8:53; dot, doq: dns.google, dns.google:853; doh: dns.google, dns.google/dns-query
+type DNS_Type int32
+
+const (
+	DNS_reserve DNS_Type = 0
+	DNS_udp     DNS_Type = 1
+	DNS_tcp     DNS_Type = 2
+	DNS_dot     DNS_Type = 3
+	DNS_doh     DNS_Type = 4
+	DNS_doq     DNS_Type = 5
+)
+
+// Enum value maps for DNS_Type.
+var (
+	DNS_Type_name = map[int32]string{
+		0: "reserve",
+		1: "udp",
+		2: "tcp",
+		3: "dot",
+		4: "doh",
+		5: "doq",
+	}
+	DNS_Type_value = map[string]int32{
+		"reserve": 0,
+		"udp":     1,
+		"tcp":     2,
+		"dot":     3,
+		"doh":     4,
+		"doq":     5,
+	}
+)
+
+func (x DNS_Type) Enum() *DNS_Type {
+	p := new(DNS_Type)
+	*p = x
+	return p
+}
+
+func (x DNS_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DNS_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_config_config_proto_enumTypes[1].Descriptor()
+}
+
+func (DNS_Type) Type() protoreflect.EnumType {
+	return &file_internal_config_config_proto_enumTypes[1]
+}
+
+func (x DNS_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DNS_Type.Descriptor instead.
+func (DNS_Type) EnumDescriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{4, 1}
+}
+
+// how to use the host and upstreams
+// sequential: try one by one, race: use the first answer,
+// fallback: use the answer of the host if all ips match the fallback_filter, otherwise the first answer of the upstreams
+type DNS_Mode int32
+
+const (
+	DNS_sequential DNS_Mode = 0
+	DNS_race       DNS_Mode = 1
+	DNS_fallback   DNS_Mode = 2
+)
+
+// Enum value maps for DNS_Mode.
+var (
+	DNS_Mode_name = map[int32]string{
+		0: "sequential",
+		1: "race",
+		2: "fallback",
+	}
+	DNS_Mode_value = map[string]int32{
+		"sequential": 0,
+		"race":       1,
+		"fallback":   2,
+	}
+)
+
+func (x DNS_Mode) Enum() *DNS_Mode {
+	p := new(DNS_Mode)
+	*p = x
+	return p
+}
+
+func (x DNS_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DNS_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_config_config_proto_enumTypes[2].Descriptor()
+}
+
+func (DNS_Mode) Type() protoreflect.EnumType {
+	return &file_internal_config_config_proto_enumTypes[2]
+}
+
+func (x DNS_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DNS_Mode.Descriptor instead.
+func (DNS_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{4, 2}
+}
+
 type Setting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -36,6 +201,11 @@ type Setting struct {
 	Proxy       *Proxy       `protobuf:"bytes,3,opt,name=Proxy,json=proxy,proto3" json:"Proxy,omitempty"`
 	DNS         *DNS         `protobuf:"bytes,4,opt,name=DNS,json=dns,proto3" json:"DNS,omitempty"`
 	LocalDNS    *DNS         `protobuf:"bytes,5,opt,name=LocalDNS,json=local_dns,proto3" json:"LocalDNS,omitempty"`
+	FakeDNS     *FakeDNS     `protobuf:"bytes,6,opt,name=FakeDNS,json=fake_dns,proto3" json:"FakeDNS,omitempty"`
+	// the static mapping answered by the dns and used by the bypass before dialing,
+	// key: example.com or *.example.com for the subdomains,
+	// value: 10.0.0.1, 10.0.0.1,fd00::1, an alias domain, or with the port overriding the dialed port, eg: 10.0.0.1:8080
+	Hosts map[string]string `protobuf:"bytes,7,rep,name=hosts,proto3" json:"hosts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Deprecated: Do not use.
 	SsrPath string `protobuf:"bytes,11,opt,name=SsrPath,json=ssr_path,proto3" json:"SsrPath,omitempty"`
 }
@@ -107,6 +277,20 @@ func (x *Setting) GetLocalDNS() *DNS {
 	return nil
 }
 
+func (x *Setting) GetFakeDNS() *FakeDNS {
+	if x != nil {
+		return x.FakeDNS
+	}
+	return nil
+}
+
+func (x *Setting) GetHosts() map[string]string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
 // Deprecated: Do not use.
 func (x *Setting) GetSsrPath() string {
 	if x != nil {
@@ -183,8 +367,16 @@ type Bypass struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled    bool   `protobuf:"varint,1,opt,name=Enabled,json=enabled,proto3" json:"Enabled,omitempty"`
-	BypassFile string `protobuf:"bytes,2,opt,name=BypassFile,json=bypass_file,proto3" json:"BypassFile,omitempty"`
+	Enabled    bool            `protobuf:"varint,1,opt,name=Enabled,json=enabled,proto3" json:"Enabled,omitempty"`
+	BypassFile string          `protobuf:"bytes,2,opt,name=BypassFile,json=bypass_file,proto3" json:"BypassFile,omitempty"`
+	Providers  []*RuleProvider `protobuf:"bytes,3,rep,name=providers,proto3" json:"providers,omitempty"`
+	// MaxMind mmdb or v2ray geoip.dat, for GEOIP rules
+	GeoipFile string `protobuf:"bytes,4,opt,name=geoip_file,proto3" json:"geoip_file,omitempty"`
+	// v2ray geosite.dat, for geosite rules
+	GeositeFile string `protobuf:"bytes,5,opt,name=geosite_file,proto3" json:"geosite_file,omitempty"`
+	// evaluate the rules in file order, the first matched rule wins,
+	// the bypass file is evaluated before the rule providers
+	Ordered bool `protobuf:"varint,6,opt,name=ordered,proto3" json:"ordered,omitempty"`
 }
 
 func (x *Bypass) Reset() {
@@ -233,21 +425,143 @@ func (x *Bypass) GetBypassFile() string {
 	return ""
 }
 
+func (x *Bypass) GetProviders() []*RuleProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Bypass) GetGeoipFile() string {
+	if x != nil {
+		return x.GeoipFile
+	}
+	return ""
+}
+
+func (x *Bypass) GetGeositeFile() string {
+	if x != nil {
+		return x.GeositeFile
+	}
+	return ""
+}
+
+func (x *Bypass) GetOrdered() bool {
+	if x != nil {
+		return x.Ordered
+	}
+	return false
+}
+
+// remote rules, same format as the bypass file
+type RuleProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url  string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// refresh interval, seconds
+	Interval int64 `protobuf:"varint,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// download through the proxy
+	Proxy bool `protobuf:"varint,4,opt,name=proxy,proto3" json:"proxy,omitempty"`
+}
+
+func (x *RuleProvider) Reset() {
+	*x = RuleProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_config_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleProvider) ProtoMessage() {}
+
+func (x *RuleProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_config_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleProvider.ProtoReflect.Descriptor instead.
+func (*RuleProvider) Descriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RuleProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RuleProvider) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RuleProvider) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *RuleProvider) GetProxy() bool {
+	if x != nil {
+		return x.Proxy
+	}
+	return false
+}
+
 type DNS struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Host   string `protobuf:"bytes,1,opt,name=Host,json=host,proto3" json:"Host,omitempty"`
-	DOH    bool   `protobuf:"varint,2,opt,name=DOH,json=doh,proto3" json:"DOH,omitempty"`
-	Proxy  bool   `protobuf:"varint,3,opt,name=Proxy,json=proxy,proto3" json:"Proxy,omitempty"`
-	Subnet string `protobuf:"bytes,4,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	Host string `protobuf:"bytes,1,opt,name=Host,json=host,proto3" json:"Host,omitempty"`
+	// replaced by type, used only if the type is reserve
+	//
+	// Deprecated: Do not use.
+	DOH bool `protobuf:"varint,2,opt,name=DOH,json=doh,proto3" json:"DOH,omitempty"`
+	// resolve through the proxy, the node servers are always resolved by the local_dns directly
+	Proxy    bool         `protobuf:"varint,3,opt,name=Proxy,json=proxy,proto3" json:"Proxy,omitempty"`
+	Subnet   string       `protobuf:"bytes,4,opt,name=subnet,proto3" json:"subnet,omitempty"`
+	Strategy DNS_Strategy `protobuf:"varint,5,opt,name=strategy,proto3,enum=yuhaiin.api.DNS_Strategy" json:"strategy,omitempty"`
+	Type     DNS_Type     `protobuf:"varint,6,opt,name=type,proto3,enum=yuhaiin.api.DNS_Type" json:"type,omitempty"`
+	// the outbound when the proxy is enabled, empty for the now node, eg: <hash>, hash=<hash>, group=<group>
+	Outbound string `protobuf:"bytes,7,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	// the upstreams besides the host, they share the subnet, strategy, proxy and outbound
+	Upstreams []*DNSUpstream `protobuf:"bytes,8,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	Mode      DNS_Mode       `protobuf:"varint,9,opt,name=mode,proto3,enum=yuhaiin.api.DNS_Mode" json:"mode,omitempty"`
+	// the ips of the host answer trusted in the fallback mode, eg: geoip:cn, 10.0.0.0/8
+	FallbackFilter []string `protobuf:"bytes,10,rep,name=fallback_filter,proto3" json:"fallback_filter,omitempty"`
+	// validate the answers by dnssec, the bogus answers are refused
+	Dnssec bool `protobuf:"varint,11,opt,name=dnssec,proto3" json:"dnssec,omitempty"`
+	// the trust anchors in the DS format, eg: ". IN DS 20326 8 2 E06D...", empty for the root anchors
+	TrustAnchors []string `protobuf:"bytes,12,rep,name=trust_anchors,proto3" json:"trust_anchors,omitempty"`
+	// serve the expired answers up to cache_stale seconds while refreshing in background,
+	// 0 for the default 86400, negative to disable
+	CacheStale int64 `protobuf:"varint,13,opt,name=cache_stale,proto3" json:"cache_stale,omitempty"`
 }
 
 func (x *DNS) Reset() {
 	*x = DNS{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_config_config_proto_msgTypes[3]
+		mi := &file_internal_config_config_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -260,7 +574,7 @@ func (x *DNS) String() string {
 func (*DNS) ProtoMessage() {}
 
 func (x *DNS) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_config_config_proto_msgTypes[3]
+	mi := &file_internal_config_config_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +587,7 @@ func (x *DNS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DNS.ProtoReflect.Descriptor instead.
 func (*DNS) Descriptor() ([]byte, []int) {
-	return file_internal_config_config_proto_rawDescGZIP(), []int{3}
+	return file_internal_config_config_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DNS) GetHost() string {
@@ -283,6 +597,7 @@ func (x *DNS) GetHost() string {
 	return ""
 }
 
+// Deprecated: Do not use.
 func (x *DNS) GetDOH() bool {
 	if x != nil {
 		return x.DOH
@@ -304,6 +619,201 @@ func (x *DNS) GetSubnet() string {
 	return ""
 }
 
+func (x *DNS) GetStrategy() DNS_Strategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return DNS_prefer_ipv4
+}
+
+func (x *DNS) GetType() DNS_Type {
+	if x != nil {
+		return x.Type
+	}
+	return DNS_reserve
+}
+
+func (x *DNS) GetOutbound() string {
+	if x != nil {
+		return x.Outbound
+	}
+	return ""
+}
+
+func (x *DNS) GetUpstreams() []*DNSUpstream {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+func (x *DNS) GetMode() DNS_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return DNS_sequential
+}
+
+func (x *DNS) GetFallbackFilter() []string {
+	if x != nil {
+		return x.FallbackFilter
+	}
+	return nil
+}
+
+func (x *DNS) GetDnssec() bool {
+	if x != nil {
+		return x.Dnssec
+	}
+	return false
+}
+
+func (x *DNS) GetTrustAnchors() []string {
+	if x != nil {
+		return x.TrustAnchors
+	}
+	return nil
+}
+
+func (x *DNS) GetCacheStale() int64 {
+	if x != nil {
+		return x.CacheStale
+	}
+	return 0
+}
+
+type DNSUpstream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// reserve is udp
+	Type DNS_Type `protobuf:"varint,2,opt,name=type,proto3,enum=yuhaiin.api.DNS_Type" json:"type,omitempty"`
+}
+
+func (x *DNSUpstream) Reset() {
+	*x = DNSUpstream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_config_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSUpstream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSUpstream) ProtoMessage() {}
+
+func (x *DNSUpstream) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_config_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSUpstream.ProtoReflect.Descriptor instead.
+func (*DNSUpstream) Descriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DNSUpstream) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DNSUpstream) GetType() DNS_Type {
+	if x != nil {
+		return x.Type
+	}
+	return DNS_reserve
+}
+
+// answer the A queries with the fake ips, the redir and tproxy map the fake ips back to the domains
+type FakeDNS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// the udp listen address of the fake dns server, eg: 127.0.0.1:5353
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// the ipv4 cidr of the fake ips, default: 198.18.0.0/15
+	Pool string `protobuf:"bytes,3,opt,name=pool,proto3" json:"pool,omitempty"`
+	// the domains resolved by the DNS instead of the fake ips, same format as the domain of bypass rules,
+	// eg: *.lan, full:time.windows.com, keyword:ntp, geosite:private
+	Exclude []string `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"`
+}
+
+func (x *FakeDNS) Reset() {
+	*x = FakeDNS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_config_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FakeDNS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FakeDNS) ProtoMessage() {}
+
+func (x *FakeDNS) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_config_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FakeDNS.ProtoReflect.Descriptor instead.
+func (*FakeDNS) Descriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FakeDNS) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FakeDNS) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *FakeDNS) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *FakeDNS) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
 type Proxy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -312,12 +822,18 @@ type Proxy struct {
 	HTTP   string `protobuf:"bytes,1,opt,name=HTTP,json=http,proto3" json:"HTTP,omitempty"`
 	Socks5 string `protobuf:"bytes,2,opt,name=Socks5,json=socks5,proto3" json:"Socks5,omitempty"`
 	Redir  string `protobuf:"bytes,3,opt,name=Redir,json=redir,proto3" json:"Redir,omitempty"`
+	// the routing policies of the listeners, key: http, socks5, redir, dns
+	Policies map[string]*InboundPolicy `protobuf:"bytes,4,rep,name=policies,proto3" json:"policies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the udp and tcp dns server, the domains of direct rules are resolved by the local dns, others by the dns
+	DNS string `protobuf:"bytes,5,opt,name=DNS,json=dns,proto3" json:"DNS,omitempty"`
+	// answer the A/AAAA queries of the block rules with 0.0.0.0/::, otherwise NXDOMAIN
+	DnsBlockZeroIp bool `protobuf:"varint,6,opt,name=dns_block_zero_ip,proto3" json:"dns_block_zero_ip,omitempty"`
 }
 
 func (x *Proxy) Reset() {
 	*x = Proxy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_config_config_proto_msgTypes[4]
+		mi := &file_internal_config_config_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -330,7 +846,7 @@ func (x *Proxy) String() string {
 func (*Proxy) ProtoMessage() {}
 
 func (x *Proxy) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_config_config_proto_msgTypes[4]
+	mi := &file_internal_config_config_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +859,7 @@ func (x *Proxy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Proxy.ProtoReflect.Descriptor instead.
 func (*Proxy) Descriptor() ([]byte, []int) {
-	return file_internal_config_config_proto_rawDescGZIP(), []int{4}
+	return file_internal_config_config_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Proxy) GetHTTP() string {
@@ -367,6 +883,85 @@ func (x *Proxy) GetRedir() string {
 	return ""
 }
 
+func (x *Proxy) GetPolicies() map[string]*InboundPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *Proxy) GetDNS() string {
+	if x != nil {
+		return x.DNS
+	}
+	return ""
+}
+
+func (x *Proxy) GetDnsBlockZeroIp() bool {
+	if x != nil {
+		return x.DnsBlockZeroIp
+	}
+	return false
+}
+
+// the listener without policy uses the default bypass
+type InboundPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the bypass file of the listener, uses the geoip, geosite and ordered setting of bypass
+	BypassFile string `protobuf:"bytes,1,opt,name=bypass_file,proto3" json:"bypass_file,omitempty"`
+	// the fixed outbound, override the bypass_file, eg: direct, proxy, proxy:<hash>, proxy:group=<group>
+	Outbound string `protobuf:"bytes,2,opt,name=outbound,proto3" json:"outbound,omitempty"`
+}
+
+func (x *InboundPolicy) Reset() {
+	*x = InboundPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_config_config_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InboundPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboundPolicy) ProtoMessage() {}
+
+func (x *InboundPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_config_config_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboundPolicy.ProtoReflect.Descriptor instead.
+func (*InboundPolicy) Descriptor() ([]byte, []int) {
+	return file_internal_config_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InboundPolicy) GetBypassFile() string {
+	if x != nil {
+		return x.BypassFile
+	}
+	return ""
+}
+
+func (x *InboundPolicy) GetOutbound() string {
+	if x != nil {
+		return x.Outbound
+	}
+	return ""
+}
+
 var File_internal_config_config_proto protoreflect.FileDescriptor
 
 var file_internal_config_config_proto_rawDesc = []byte{
@@ -374,7 +969,7 @@ var file_internal_config_config_proto_rawDesc = []byte{
 	0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b,
 	0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
-	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x74,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb1, 0x03, 0x0a, 0x07, 0x53, 0x65, 0x74,
 	0x74, 0x69, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72,
 	0x6f, 0x78, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x79, 0x75, 0x68, 0x61,
 	0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72,
@@ -389,40 +984,131 @@ var file_internal_config_config_proto_rawDesc = []byte{
 	0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x08,
 	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x4e, 0x53, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
 	0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53,
-	0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x53,
-	0x73, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01,
-	0x52, 0x08, 0x73, 0x73, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x22, 0x53, 0x0a, 0x0b, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x63, 0x6b, 0x73,
-	0x35, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x73, 0x35, 0x22,
-	0x43, 0x0a, 0x06, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0a, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x46, 0x69, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x79, 0x70, 0x61, 0x73, 0x73, 0x5f,
-	0x66, 0x69, 0x6c, 0x65, 0x22, 0x59, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x48,
-	0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x44, 0x4f, 0x48, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64, 0x6f,
-	0x68, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x22,
-	0x49, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x16, 0x0a, 0x06,
-	0x53, 0x6f, 0x63, 0x6b, 0x73, 0x35, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
-	0x63, 0x6b, 0x73, 0x35, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x72, 0x32, 0x78, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x61, 0x6f, 0x12, 0x34, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69,
-	0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x34,
-	0x0a, 0x04, 0x73, 0x61, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x16, 0x2e, 0x67,
+	0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x46,
+	0x61, 0x6b, 0x65, 0x44, 0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x79,
+	0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x61, 0x6b, 0x65, 0x44,
+	0x4e, 0x53, 0x52, 0x08, 0x66, 0x61, 0x6b, 0x65, 0x5f, 0x64, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x05,
+	0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x79, 0x75,
+	0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x53, 0x73, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x73, 0x73, 0x72, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x1a, 0x38, 0x0a, 0x0a, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53, 0x0a, 0x0b,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x63,
+	0x6b, 0x73, 0x35, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x73,
+	0x35, 0x22, 0xda, 0x01, 0x0a, 0x06, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0a, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73,
+	0x46, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x79, 0x70, 0x61,
+	0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x79, 0x75, 0x68,
+	0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x67, 0x65, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x65, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x22, 0x66,
+	0x0a, 0x0c, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x22, 0x86, 0x05, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x12, 0x12,
+	0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x03, 0x44, 0x4f, 0x48, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x03, 0x64, 0x6f, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x78,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69,
+	0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53, 0x2e, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x29, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x79, 0x75,
+	0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69,
+	0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x09, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x79, 0x75, 0x68,
+	0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x4e, 0x53, 0x2e, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x66, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0f, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6e, 0x73, 0x73, 0x65, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x6e, 0x73, 0x73, 0x65, 0x63, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c,
+	0x65, 0x22, 0x4a, 0x0a, 0x08, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x0f, 0x0a,
+	0x0b, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b,
+	0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x10, 0x03, 0x22, 0x40, 0x0a,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x75, 0x64, 0x70, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x74,
+	0x63, 0x70, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x64, 0x6f, 0x74, 0x10, 0x03, 0x12, 0x07, 0x0a,
+	0x03, 0x64, 0x6f, 0x68, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x64, 0x6f, 0x71, 0x10, 0x05, 0x22,
+	0x2e, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x65, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x10, 0x02, 0x22,
+	0x4c, 0x0a, 0x0b, 0x44, 0x4e, 0x53, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x4e, 0x53, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x65, 0x0a,
+	0x07, 0x46, 0x61, 0x6b, 0x65, 0x44, 0x4e, 0x53, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x22, 0xa0, 0x02, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x74,
+	0x74, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x35, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x73, 0x35, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x65,
+	0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x72,
+	0x12, 0x3c, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x44, 0x4e, 0x53, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73,
+	0x12, 0x2c, 0x0a, 0x11, 0x64, 0x6e, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x7a, 0x65,
+	0x72, 0x6f, 0x5f, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64, 0x6e, 0x73,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x5f, 0x69, 0x70, 0x1a, 0x57,
+	0x0a, 0x0d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49,
+	0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x0d, 0x49, 0x6e, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x79, 0x70, 0x61,
+	0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
+	0x79, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x75,
+	0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x75,
+	0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x78, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x64, 0x61, 0x6f, 0x12, 0x34, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x41, 0x73, 0x75, 0x74, 0x6f, 0x72, 0x75, 0x66, 0x61, 0x2f, 0x79, 0x75, 0x68,
-	0x61, 0x69, 0x69, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x34, 0x0a, 0x04, 0x73, 0x61,
+	0x76, 0x65, 0x12, 0x14, 0x2e, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41,
+	0x73, 0x75, 0x74, 0x6f, 0x72, 0x75, 0x66, 0x61, 0x2f, 0x79, 0x75, 0x68, 0x61, 0x69, 0x69, 0x6e,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -437,30 +1123,50 @@ func file_internal_config_config_proto_rawDescGZIP() []byte {
 	return file_internal_config_config_proto_rawDescData
 }
 
-var file_internal_config_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_config_config_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_internal_config_config_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_internal_config_config_proto_goTypes = []interface{}{
-	(*Setting)(nil),       // 0: yuhaiin.api.Setting
-	(*SystemProxy)(nil),   // 1: yuhaiin.api.SystemProxy
-	(*Bypass)(nil),        // 2: yuhaiin.api.Bypass
-	(*DNS)(nil),           // 3: yuhaiin.api.DNS
-	(*Proxy)(nil),         // 4: yuhaiin.api.Proxy
-	(*emptypb.Empty)(nil), // 5: google.protobuf.Empty
+	(DNS_Strategy)(0),     // 0: yuhaiin.api.DNS.Strategy
+	(DNS_Type)(0),         // 1: yuhaiin.api.DNS.Type
+	(DNS_Mode)(0),         // 2: yuhaiin.api.DNS.Mode
+	(*Setting)(nil),       // 3: yuhaiin.api.Setting
+	(*SystemProxy)(nil),   // 4: yuhaiin.api.SystemProxy
+	(*Bypass)(nil),        // 5: yuhaiin.api.Bypass
+	(*RuleProvider)(nil),  // 6: yuhaiin.api.RuleProvider
+	(*DNS)(nil),           // 7: yuhaiin.api.DNS
+	(*DNSUpstream)(nil),   // 8: yuhaiin.api.DNSUpstream
+	(*FakeDNS)(nil),       // 9: yuhaiin.api.FakeDNS
+	(*Proxy)(nil),         // 10: yuhaiin.api.Proxy
+	(*InboundPolicy)(nil), // 11: yuhaiin.api.InboundPolicy
+	nil,                   // 12: yuhaiin.api.Setting.HostsEntry
+	nil,                   // 13: yuhaiin.api.Proxy.PoliciesEntry
+	(*emptypb.Empty)(nil), // 14: google.protobuf.Empty
 }
 var file_internal_config_config_proto_depIdxs = []int32{
-	1, // 0: yuhaiin.api.Setting.SystemProxy:type_name -> yuhaiin.api.SystemProxy
-	2, // 1: yuhaiin.api.Setting.Bypass:type_name -> yuhaiin.api.Bypass
-	4, // 2: yuhaiin.api.Setting.Proxy:type_name -> yuhaiin.api.Proxy
-	3, // 3: yuhaiin.api.Setting.DNS:type_name -> yuhaiin.api.DNS
-	3, // 4: yuhaiin.api.Setting.LocalDNS:type_name -> yuhaiin.api.DNS
-	5, // 5: yuhaiin.api.config_dao.load:input_type -> google.protobuf.Empty
-	0, // 6: yuhaiin.api.config_dao.save:input_type -> yuhaiin.api.Setting
-	0, // 7: yuhaiin.api.config_dao.load:output_type -> yuhaiin.api.Setting
-	5, // 8: yuhaiin.api.config_dao.save:output_type -> google.protobuf.Empty
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	4,  // 0: yuhaiin.api.Setting.SystemProxy:type_name -> yuhaiin.api.SystemProxy
+	5,  // 1: yuhaiin.api.Setting.Bypass:type_name -> yuhaiin.api.Bypass
+	10, // 2: yuhaiin.api.Setting.Proxy:type_name -> yuhaiin.api.Proxy
+	7,  // 3: yuhaiin.api.Setting.DNS:type_name -> yuhaiin.api.DNS
+	7,  // 4: yuhaiin.api.Setting.LocalDNS:type_name -> yuhaiin.api.DNS
+	9,  // 5: yuhaiin.api.Setting.FakeDNS:type_name -> yuhaiin.api.FakeDNS
+	12, // 6: yuhaiin.api.Setting.hosts:type_name -> yuhaiin.api.Setting.HostsEntry
+	6,  // 7: yuhaiin.api.Bypass.providers:type_name -> yuhaiin.api.RuleProvider
+	0,  // 8: yuhaiin.api.DNS.strategy:type_name -> yuhaiin.api.DNS.Strategy
+	1,  // 9: yuhaiin.api.DNS.type:type_name -> yuhaiin.api.DNS.Type
+	8,  // 10: yuhaiin.api.DNS.upstreams:type_name -> yuhaiin.api.DNSUpstream
+	2,  // 11: yuhaiin.api.DNS.mode:type_name -> yuhaiin.api.DNS.Mode
+	1,  // 12: yuhaiin.api.DNSUpstream.type:type_name -> yuhaiin.api.DNS.Type
+	13, // 13: yuhaiin.api.Proxy.policies:type_name -> yuhaiin.api.Proxy.PoliciesEntry
+	11, // 14: yuhaiin.api.Proxy.PoliciesEntry.value:type_name -> yuhaiin.api.InboundPolicy
+	14, // 15: yuhaiin.api.config_dao.load:input_type -> google.protobuf.Empty
+	3,  // 16: yuhaiin.api.config_dao.save:input_type -> yuhaiin.api.Setting
+	3,  // 17: yuhaiin.api.config_dao.load:output_type -> yuhaiin.api.Setting
+	14, // 18: yuhaiin.api.config_dao.save:output_type -> google.protobuf.Empty
+	17, // [17:19] is the sub-list for method output_type
+	15, // [15:17] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_internal_config_config_proto_init() }
@@ -506,7 +1212,7 @@ func file_internal_config_config_proto_init() {
 			}
 		}
 		file_internal_config_config_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DNS); i {
+			switch v := v.(*RuleProvider); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -518,6 +1224,42 @@ func file_internal_config_config_proto_init() {
 			}
 		}
 		file_internal_config_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_config_config_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSUpstream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_config_config_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FakeDNS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_config_config_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Proxy); i {
 			case 0:
 				return &v.state
@@ -529,19 +1271,32 @@ func file_internal_config_config_proto_init() {
 				return nil
 			}
 		}
+		file_internal_config_config_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InboundPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_config_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_internal_config_config_proto_goTypes,
 		DependencyIndexes: file_internal_config_config_proto_depIdxs,
+		EnumInfos:         file_internal_config_config_proto_enumTypes,
 		MessageInfos:      file_internal_config_config_proto_msgTypes,
 	}.Build()
 	File_internal_config_config_proto = out.File
@@ -35,13 +35,18 @@ func SettingDecodeJSON(dir string) (*Setting, error) {
 		},
 		DNS: &DNS{
 			Host:   "cloudflare-dns.com",
-			DOH:    true,
+			Type:   DNS_doh,
 			Proxy:  false,
 			Subnet: "0.0.0.0/32",
 		},
 		LocalDNS: &DNS{
 			Host: "223.5.5.5",
-			DOH:  true,
+			Type: DNS_doh,
+		},
+		FakeDNS: &FakeDNS{
+			Enabled: false,
+			Host:    "127.0.0.1:5353",
+			Pool:    "198.18.0.0/15",
 		},
 	}
 	data, err := ioutil.ReadFile(filepath.Join(dir, "yuhaiinConfig.json"))
@@ -103,6 +108,11 @@ func NewConfig(dir string, o ...InitFunc) (*Config, error) {
 	return cf, nil
 }
 
+// Dir the directory of config files
+func (c *Config) Dir() string {
+	return c.path
+}
+
 func (c *Config) Exec(o ...InitFunc) error {
 	for i := range o {
 		err := o[i](c.current)
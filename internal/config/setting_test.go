@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"testing"
 
 	"google.golang.org/protobuf/encoding/protojson"
@@ -72,12 +73,13 @@ func TestJsonPb(t *testing.T) {
 }
 
 func TestCreatDir(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "b", "a", "a.txt")
 _retry:
-	file, err := os.OpenFile("./b/a/a.txt", os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
+	file, err := os.OpenFile(name, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
 	if err != nil {
 		if os.IsNotExist(err) {
-			t.Log(path.Dir("./b/a/a.txt"))
-			err = os.MkdirAll(path.Dir("./b/a/a.txt"), os.ModePerm)
+			t.Log(filepath.Dir(name))
+			err = os.MkdirAll(filepath.Dir(name), os.ModePerm)
 			if err != nil {
 				t.Error(err)
 			}
@@ -0,0 +1,187 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+)
+
+//Cache the lookup cache respects the ttl of the records,
+//the NXDOMAIN and empty answers are cached for NegativeTTL,
+//the concurrent lookups of the same domain share one query
+type Cache struct {
+	// MinTTL, MaxTTL clamp the ttl of the records
+	MinTTL time.Duration
+	MaxTTL time.Duration
+	// NegativeTTL the ttl of the NXDOMAIN and empty answers
+	NegativeTTL time.Duration
+	// Stale the expired answers are served up to Stale after expired while refreshing in background, 0 disables
+	Stale time.Duration
+
+	lru    *utils.LRU
+	hits   uint64
+	misses uint64
+	stales uint64
+
+	calls     map[string]*call
+	callsLock sync.Mutex
+}
+
+type cacheEntry struct {
+	ips    []net.IP
+	err    error
+	expire time.Time
+}
+
+type call struct {
+	wg  sync.WaitGroup
+	ips []net.IP
+	err error
+}
+
+//CacheStats the statistics of the cache
+type CacheStats struct {
+	Size   int
+	Hits   uint64
+	Misses uint64
+	// Stales the lookups answered by the expired entries
+	Stales uint64
+}
+
+//NewCache create a cache with capacity size, the ttl is clamped to [1 minute, 1 hour] by default
+func NewCache(size int) *Cache {
+	return &Cache{
+		MinTTL:      time.Minute,
+		MaxTTL:      time.Hour,
+		NegativeTTL: 30 * time.Second,
+		lru:         utils.NewLru(size, 0),
+		calls:       make(map[string]*call),
+	}
+}
+
+//WithCache share the cache between the dns clients, the clients with different strategy shouldn't share one cache
+func WithCache(c *Cache) Option {
+	return func(o *option) { o.cache = c }
+}
+
+//Lookup get the ips of domain from the cache, or call f to query, f return the ips and the ttl seconds
+func (c *Cache) Lookup(domain string, f func(string) ([]net.IP, uint32, error)) ([]net.IP, error) {
+	if x, ok := c.lru.Load(domain); ok {
+		e := x.(*cacheEntry)
+		now := time.Now()
+		if now.Before(e.expire) {
+			atomic.AddUint64(&c.hits, 1)
+			return e.ips, e.err
+		}
+		if e.err == nil && now.Before(e.expire.Add(c.Stale)) {
+			atomic.AddUint64(&c.stales, 1)
+			if x, ok := c.call(domain); ok {
+				go c.do(x, domain, f)
+			}
+			return e.ips, nil
+		}
+		c.lru.Delete(domain)
+	}
+	atomic.AddUint64(&c.misses, 1)
+
+	x, ok := c.call(domain)
+	if !ok {
+		x.wg.Wait()
+		return x.ips, x.err
+	}
+	c.do(x, domain, f)
+	return x.ips, x.err
+}
+
+//call get the running query of domain, or create one, ok is true if it is created
+func (c *Cache) call(domain string) (*call, bool) {
+	c.callsLock.Lock()
+	defer c.callsLock.Unlock()
+	if x, ok := c.calls[domain]; ok {
+		return x, false
+	}
+	x := &call{}
+	x.wg.Add(1)
+	c.calls[domain] = x
+	return x, true
+}
+
+func (c *Cache) do(x *call, domain string, f func(string) ([]net.IP, uint32, error)) {
+	x.ips, x.err = c.query(domain, f)
+	x.wg.Done()
+
+	c.callsLock.Lock()
+	delete(c.calls, domain)
+	c.callsLock.Unlock()
+}
+
+func (c *Cache) query(domain string, f func(string) ([]net.IP, uint32, error)) ([]net.IP, error) {
+	ips, ttl, err := f(domain)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no address of %s", domain)
+	}
+
+	switch {
+	case err == nil:
+		d := time.Duration(ttl) * time.Second
+		if d < c.MinTTL {
+			d = c.MinTTL
+		}
+		if d > c.MaxTTL {
+			d = c.MaxTTL
+		}
+		c.lru.Add(domain, &cacheEntry{ips: ips, expire: time.Now().Add(d)})
+	case errors.Is(err, ErrNoSuchName) || (len(ips) == 0 && ttl != 0):
+		// the server answered without addresses, the network errors are not cached
+		c.lru.Add(domain, &cacheEntry{err: err, expire: time.Now().Add(c.NegativeTTL)})
+	}
+	return ips, err
+}
+
+//Stats get the size and the hit/miss counters
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Size:   c.lru.Len(),
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+		Stales: atomic.LoadUint64(&c.stales),
+	}
+}
+
+//CacheEntry the answer of a domain in the cache
+type CacheEntry struct {
+	Domain string   `json:"domain"`
+	IPs    []net.IP `json:"ips"`
+	// TTL the remaining seconds, negative if expired
+	TTL int64 `json:"ttl"`
+}
+
+//Entries the answers with addresses, from the most recently used, the negative answers are not included
+func (c *Cache) Entries() []CacheEntry {
+	now := time.Now()
+	var s []CacheEntry
+	c.lru.Range(func(key, value interface{}) bool {
+		e := value.(*cacheEntry)
+		if e.err == nil && len(e.ips) != 0 {
+			s = append(s, CacheEntry{Domain: key.(string), IPs: e.ips, TTL: int64(e.expire.Sub(now) / time.Second)})
+		}
+		return true
+	})
+	return s
+}
+
+//Restore add the entries, they expire after the remaining ttl since the time saved
+func (c *Cache) Restore(saved time.Time, entries []CacheEntry) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Domain == "" || len(e.IPs) == 0 {
+			continue
+		}
+		c.lru.Add(e.Domain, &cacheEntry{ips: e.IPs, expire: saved.Add(time.Duration(e.TTL) * time.Second)})
+	}
+}
@@ -0,0 +1,118 @@
+package dns
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(10)
+	c.MinTTL = 0
+
+	var count int32
+	f := func(domain string) ([]net.IP, uint32, error) {
+		atomic.AddInt32(&count, 1)
+		switch domain {
+		case "nx.com":
+			return nil, 0, ErrNoSuchName
+		case "err.com":
+			return nil, 0, errors.New("timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []net.IP{net.ParseIP("1.2.3.4")}, 1, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ips, err := c.Lookup("www.example.com", f)
+			if err != nil || len(ips) != 1 {
+				t.Error(ips, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if count != 1 {
+		t.Errorf("the concurrent lookups should share one query, got %d", count)
+	}
+
+	_, _ = c.Lookup("www.example.com", f)
+	if s := c.Stats(); s.Size != 1 || s.Hits == 0 || count != 1 {
+		t.Errorf("want answered by cache, got %+v, %d", s, count)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	_, _ = c.Lookup("www.example.com", f)
+	if count != 2 {
+		t.Errorf("the ttl is expired, want query again, got %d", count)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Lookup("nx.com", f); !errors.Is(err, ErrNoSuchName) {
+			t.Error(err)
+		}
+		_, _ = c.Lookup("err.com", f)
+	}
+	if count != 5 {
+		t.Errorf("want NXDOMAIN cached and network error not cached, got %d", count)
+	}
+}
+
+func TestCacheStale(t *testing.T) {
+	c := NewCache(10)
+	c.MinTTL, c.Stale = 0, time.Minute
+
+	var count int32
+	f := func(domain string) ([]net.IP, uint32, error) {
+		n := atomic.AddInt32(&count, 1)
+		return []net.IP{net.IPv4(1, 2, 3, byte(n))}, 1, nil
+	}
+	_, _ = c.Lookup("www.example.com", f)
+	time.Sleep(1100 * time.Millisecond)
+
+	ips, err := c.Lookup("www.example.com", f)
+	if err != nil || !ips[0].Equal(net.IPv4(1, 2, 3, 1)) {
+		t.Errorf("want the stale answer, got %v %v", ips, err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	ips, _ = c.Lookup("www.example.com", f)
+	if !ips[0].Equal(net.IPv4(1, 2, 3, 2)) || atomic.LoadInt32(&count) != 2 {
+		t.Errorf("want the refreshed answer, got %v %d", ips, count)
+	}
+	if s := c.Stats(); s.Stales != 1 {
+		t.Errorf("want one stale lookup, got %+v", s)
+	}
+}
+
+func TestCacheRestore(t *testing.T) {
+	c := NewCache(10)
+	_, _ = c.Lookup("a.com", func(string) ([]net.IP, uint32, error) {
+		return []net.IP{net.IPv4(1, 1, 1, 1)}, 300, nil
+	})
+	_, _ = c.Lookup("nx.com", func(string) ([]net.IP, uint32, error) { return nil, 0, ErrNoSuchName })
+
+	entries := c.Entries()
+	if len(entries) != 1 || entries[0].Domain != "a.com" || entries[0].TTL < 290 {
+		t.Fatalf("want the entry of a.com, got %+v", entries)
+	}
+
+	failed := func(string) ([]net.IP, uint32, error) { return nil, 0, errors.New("network is down") }
+	c2 := NewCache(10)
+	c2.Restore(time.Now(), entries)
+	if ips, err := c2.Lookup("a.com", failed); err != nil || !ips[0].Equal(net.IPv4(1, 1, 1, 1)) {
+		t.Errorf("want the restored answer, got %v %v", ips, err)
+	}
+
+	// saved 1 hour ago, expired
+	c3 := NewCache(10)
+	c3.Restore(time.Now().Add(-time.Hour), entries)
+	if _, err := c3.Lookup("a.com", failed); err == nil {
+		t.Error("want the expired entry queried again")
+	}
+}
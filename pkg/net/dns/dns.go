@@ -8,20 +8,91 @@ import (
 
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
 	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 type DNS interface {
 	LookupIP(domain string) ([]net.IP, error)
 	Resolver() *net.Resolver
+	// Do send the dns request message to the server, return the response message
+	Do(req []byte) ([]byte, error)
 }
 
-func dnsHandle(domain string, subnet *net.IPNet, f func([]byte) ([]byte, error)) ([]net.IP, error) {
-	req := createEDNSReq(domain, A, createEdnsClientSubnet(subnet))
+//Option the option of the dns clients
+type Option func(*option)
+
+type option struct {
+	strategy utils.Strategy
+	cache    *Cache
+	trust    func(net.IP) bool
+	hosts    *Hosts
+}
+
+//WithStrategy set the address family strategy, default: prefer ipv4
+func WithStrategy(s utils.Strategy) Option {
+	return func(o *option) { o.strategy = s }
+}
+
+func newOption(opts []Option) option {
+	o := option{}
+	for i := range opts {
+		opts[i](&o)
+	}
+	if o.cache == nil {
+		o.cache = NewCache(200)
+	}
+	return o
+}
+
+func dnsHandle(domain string, reqType Type, subnet *net.IPNet, f func([]byte) ([]byte, error)) ([]net.IP, uint32, error) {
+	req, err := NewRequest(domain, reqType, clientSubnetOption(subnet))
+	if err != nil {
+		return nil, 0, err
+	}
 	b, err := f(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	return resolveTTL(req, b)
+}
+
+//lookupIP query A or/and AAAA by the strategy, the dual stack queries are sent concurrently,
+//it fails only if all queries fail, return the ips and the min ttl
+func lookupIP(domain string, subnet *net.IPNet, s utils.Strategy, f func([]byte) ([]byte, error)) ([]net.IP, uint32, error) {
+	if !s.UseIPv6() || !s.UseIPv4() {
+		t := A
+		if !s.UseIPv4() {
+			t = AAAA
+		}
+		ips, ttl, err := dnsHandle(domain, t, subnet, f)
+		if err != nil {
+			return nil, 0, err
+		}
+		return s.Apply(ips), ttl, nil
+	}
+
+	type result struct {
+		ips []net.IP
+		ttl uint32
+		err error
+	}
+	c := make(chan result, 1)
+	go func() {
+		ips, ttl, err := dnsHandle(domain, AAAA, subnet, f)
+		c <- result{ips, ttl, err}
+	}()
+
+	ips, ttl, err := dnsHandle(domain, A, subnet, f)
+	r := <-c
+	switch {
+	case err != nil && r.err != nil:
+		return nil, 0, err
+	case err != nil:
+		ttl = r.ttl
+	case r.err == nil && r.ttl < ttl:
+		ttl = r.ttl
 	}
-	return Resolve(req, b)
+	return s.Apply(append(ips, r.ips...)), ttl, nil
 }
 
 var _ DNS = (*dns)(nil)
@@ -30,11 +101,11 @@ type dns struct {
 	DNS
 	Server string
 	Subnet *net.IPNet
-	cache  *utils.LRU
 	proxy  proxy.Proxy
+	option
 }
 
-func NewDNS(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
+func NewDNS(host string, subnet *net.IPNet, p proxy.Proxy, opts ...Option) DNS {
 	if subnet == nil {
 		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
 	}
@@ -44,24 +115,26 @@ func NewDNS(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
 	}
 
 	return &dns{
-		Server: host,
+		Server: withPort(host, "53"),
 		Subnet: subnet,
-		cache:  utils.NewLru(200, 20*time.Minute),
 		proxy:  p,
+		option: newOption(opts),
 	}
 }
 
 // LookupIP resolve domain return net.IP array
-func (n *dns) LookupIP(domain string) (DNS []net.IP, err error) {
-	if x, _ := n.cache.Load(domain); x != nil {
-		return x.([]net.IP), nil
-	}
-	DNS, err = dnsHandle(domain, n.Subnet, n.udp)
-	if err != nil || len(DNS) == 0 {
-		return nil, fmt.Errorf("normal resolve domain %s failed: %v", domain, err)
+func (n *dns) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := n.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, n.Subnet, n.strategy, n.udp)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("normal resolve domain %s failed: %w", domain, err)
 	}
-	n.cache.Add(domain, DNS)
-	return
+	return ips, nil
+}
+
+func (n *dns) Do(req []byte) ([]byte, error) {
+	return n.hostsDo(req, n.udp)
 }
 
 func (n *dns) Resolver() *net.Resolver {
@@ -96,5 +169,24 @@ func (n *dns) udp(req []byte) (data []byte, err error) {
 	}
 
 	nn, _, err := conn.ReadFrom(b)
-	return b[:nn], err
+	if err != nil {
+		return nil, err
+	}
+
+	var p dnsmessage.Parser
+	if h, err := p.Start(b[:nn]); err == nil && h.Truncated {
+		return n.tcp(req)
+	}
+	// the buffer is put back to the pool
+	return append([]byte(nil), b[:nn]...), nil
+}
+
+//tcp retry the truncated response by tcp
+func (n *dns) tcp(req []byte) ([]byte, error) {
+	conn, err := n.proxy.Conn(n.Server)
+	if err != nil {
+		return nil, fmt.Errorf("tcp dial failed: %v", err)
+	}
+	defer conn.Close()
+	return tcpDo(conn, req)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"testing"
 
 	socks5client "github.com/Asutorufa/yuhaiin/pkg/net/proxy/socks5/client"
@@ -69,9 +70,13 @@ func TestDNS5(t *testing.T) {
 }
 
 func TestDNS6(t *testing.T) {
-	t.Log(base64.URLEncoding.EncodeToString(creatRequest("www.example.com", A, false)))
-	t.Log(base64.URLEncoding.EncodeToString(creatRequest("www.google.com", A, false)))
-	t.Log(base64.URLEncoding.EncodeToString(creatRequest("a.62characterlabel-makes-base64url-distinct-from-standard-base64.example.com", A, false)))
+	for _, d := range []string{"www.example.com", "www.google.com", "a.62characterlabel-makes-base64url-distinct-from-standard-base64.example.com"} {
+		req, err := NewRequest(d, A)
+		if err != nil {
+			t.Error(err)
+		}
+		t.Log(base64.URLEncoding.EncodeToString(req))
+	}
 }
 
 func TestDNSResolver(t *testing.T) {
@@ -83,19 +88,32 @@ func TestDNSResolver(t *testing.T) {
 }
 
 func TestResolverHeader(t *testing.T) {
-	d := creatRequest("www.example.com", A, false)
+	d, err := NewRequest("www.example.com", A)
+	if err != nil {
+		t.Fatal(err)
+	}
 	t.Log(d)
-	s := &resolver{
-		request: d,
-		aswer:   d,
+	if _, err = ParseResponse(d, d); err == nil {
+		t.Error("the request is not an answer")
 	}
-
-	s.header()
 }
 
 func TestResolve(t *testing.T) {
 	req := []byte{46, 230, 1, 0, 0, 1, 0, 0, 0, 0, 0, 1, 7, 98, 114, 111, 119, 115, 101, 114, 4, 112, 105, 112, 101, 4, 97, 114, 105, 97, 9, 109, 105, 99, 114, 111, 115, 111, 102, 116, 3, 99, 111, 109, 0, 0, 1, 0, 1, 0, 0, 41, 16, 0, 0, 0, 0, 0, 0, 12, 0, 8, 0, 8, 0, 1, 22, 0, 223, 5, 4, 0}
 	ans := []byte{46, 230, 129, 128, 0, 1, 0, 3, 0, 0, 0, 1, 7, 98, 114, 111, 119, 115, 101, 114, 4, 112, 105, 112, 101, 4, 97, 114, 105, 97, 9, 109, 105, 99, 114, 111, 115, 111, 102, 116, 3, 99, 111, 109, 0, 0, 1, 0, 1, 192, 12, 0, 5, 0, 1, 0, 0, 13, 58, 0, 40, 7, 98, 114, 111, 119, 115, 101, 114, 6, 101, 118, 101, 110, 116, 115, 4, 100, 97, 116, 97, 14, 116, 114, 97, 102, 102, 105, 99, 109, 97, 110, 97, 103, 101, 114, 3, 110, 101, 116, 0, 192, 61, 0, 5, 0, 1, 0, 0, 0, 43, 0, 32, 20, 115, 107, 121, 112, 101, 100, 97, 116, 97, 112, 114, 100, 99, 111, 108, 99, 117, 115, 49, 50, 8, 99, 108, 111, 117, 100, 97, 112, 112, 192, 96, 192, 113, 0, 1, 0, 1, 0, 0, 0, 6, 0, 4, 13, 89, 202, 241, 0, 0, 41, 16, 0, 0, 0, 0, 0, 0, 0}
 
-	t.Log(Resolve(req, ans))
+	ips, err := Resolve(req, ans)
+	t.Log(ips, err)
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(13, 89, 202, 241)) {
+		t.Errorf("want [13.89.202.241], got %v %v", ips, err)
+	}
+
+	r, _ := ParseResponse(req, ans)
+	if c := r.Chain(); len(c) != 3 || c[2].String() != "skypedataprdcolcus12.cloudapp.net." {
+		t.Errorf("unexpected cname chain: %v", c)
+	}
+	_, ttl := r.IPs()
+	if ttl != 6 {
+		t.Errorf("want the min ttl 6, got %d", ttl)
+	}
 }
@@ -1,18 +1,609 @@
 package dns
 
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
 //protocol change https://tools.ietf.org/html/rfc3225
 //https://tools.ietf.org/html/rfc4034
 //https://tools.ietf.org/html/rfc4035
 //Algorithm https://tools.ietf.org/html/rfc4034#appendix-A.1
-func createDNSSEC(domain string, reqType2 reqType) (header eDNSHeader, b []byte) {
-	//eDNSHeader := createEDNSReq(domain,reqType2,[]byte{})
-	header = eDNSHeader{}
-	header.DnsHeader = creatRequest(domain, reqType2, true)
-	header.Name[0] = 0b0
-	header.Type = [2]byte{0b00000000, 0b00101001}
-	header.PayloadSize = [2]byte{0b00010000, 0b00000000} //4096
-	header.ExtendRCode = [1]byte{0b00000000}
-	header.EDNSVersion = [1]byte{0b00000000}
-	header.Z = [2]byte{0b10000000, 0b00000000} // Do bit = 1
-	return header, createEDNSRequ(header)
+
+//the dnssec algorithms
+const (
+	RSASHA1          uint8 = 5
+	RSASHA1NSEC3SHA1 uint8 = 7
+	RSASHA256        uint8 = 8
+	RSASHA512        uint8 = 10
+	ECDSAP256SHA256  uint8 = 13
+	ECDSAP384SHA384  uint8 = 14
+	ED25519          uint8 = 15
+)
+
+//the digest types of DS
+const (
+	DigestSHA1   uint8 = 1
+	DigestSHA256 uint8 = 2
+	DigestSHA384 uint8 = 4
+)
+
+var errRecord = errors.New("invalid record")
+
+//ErrUnsupportedAlgorithm the algorithm of the key or digest is not supported
+var ErrUnsupportedAlgorithm = errors.New("unsupported dnssec algorithm")
+
+func supportedAlgorithm(alg uint8) bool {
+	switch alg {
+	case RSASHA1, RSASHA1NSEC3SHA1, RSASHA256, RSASHA512, ECDSAP256SHA256, ECDSAP384SHA384, ED25519:
+		return true
+	}
+	return false
+}
+
+func supportedDigest(t uint8) bool {
+	return t == DigestSHA1 || t == DigestSHA256 || t == DigestSHA384
+}
+
+//DNSKEYRecord the DNSKEY record
+type DNSKEYRecord struct {
+	// Flags 256: zone key, 257: zone key and secure entry point
+	Flags     uint16
+	Protocol  uint8
+	Algorithm uint8
+	PublicKey []byte
+}
+
+//ParseDNSKEY parse the rdata of the DNSKEY record
+func ParseDNSKEY(data []byte) (*DNSKEYRecord, error) {
+	if len(data) < 4 {
+		return nil, errRecord
+	}
+	return &DNSKEYRecord{
+		Flags:     binary.BigEndian.Uint16(data),
+		Protocol:  data[2],
+		Algorithm: data[3],
+		PublicKey: data[4:],
+	}, nil
+}
+
+//Data the rdata of the record
+func (k *DNSKEYRecord) Data() []byte {
+	b := []byte{byte(k.Flags >> 8), byte(k.Flags), k.Protocol, k.Algorithm}
+	return append(b, k.PublicKey...)
+}
+
+//ZoneKey the zone key flag is set and the protocol is 3
+func (k *DNSKEYRecord) ZoneKey() bool { return k.Flags&0x100 != 0 && k.Protocol == 3 }
+
+//KeyTag the key tag, https://tools.ietf.org/html/rfc4034#appendix-B
+func (k *DNSKEYRecord) KeyTag() uint16 {
+	var ac uint32
+	for i, b := range k.Data() {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return uint16(ac & 0xFFFF)
+}
+
+//DS create the DS record of the key, owner: the zone of the key
+func (k *DNSKEYRecord) DS(owner string, digestType uint8) (*DSRecord, error) {
+	name, err := packName(owner)
+	if err != nil {
+		return nil, err
+	}
+	data := append(name, k.Data()...)
+
+	ds := &DSRecord{KeyTag: k.KeyTag(), Algorithm: k.Algorithm, DigestType: digestType}
+	switch digestType {
+	case DigestSHA1:
+		h := sha1.Sum(data)
+		ds.Digest = h[:]
+	case DigestSHA256:
+		h := sha256.Sum256(data)
+		ds.Digest = h[:]
+	case DigestSHA384:
+		h := sha512.Sum384(data)
+		ds.Digest = h[:]
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return ds, nil
+}
+
+//DSRecord the DS record
+type DSRecord struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     []byte
+}
+
+//ParseDS parse the rdata of the DS record
+func ParseDS(data []byte) (*DSRecord, error) {
+	if len(data) < 5 {
+		return nil, errRecord
+	}
+	return &DSRecord{
+		KeyTag:     binary.BigEndian.Uint16(data),
+		Algorithm:  data[2],
+		DigestType: data[3],
+		Digest:     data[4:],
+	}, nil
+}
+
+//ParseDSText parse the DS record in the presentation format, return the owner and the record,
+//eg: ". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D"
+func ParseDSText(s string) (string, *DSRecord, error) {
+	fields := strings.Fields(s)
+	i := 0
+	for i < len(fields) && !strings.EqualFold(fields[i], "DS") {
+		i++
+	}
+	if i == 0 || len(fields) < i+5 {
+		return "", nil, fmt.Errorf("invalid ds record: %s", s)
+	}
+
+	var v [3]uint64
+	for j := range v {
+		x, err := strconv.ParseUint(fields[i+1+j], 10, 16)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid ds record %s: %v", s, err)
+		}
+		v[j] = x
+	}
+	digest, err := hex.DecodeString(strings.Join(fields[i+4:], ""))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid ds digest %s: %v", s, err)
+	}
+	return canonicalName(fields[0]), &DSRecord{
+		KeyTag:     uint16(v[0]),
+		Algorithm:  uint8(v[1]),
+		DigestType: uint8(v[2]),
+		Digest:     digest,
+	}, nil
+}
+
+//Match whether the DS is the digest of the key
+func (d *DSRecord) Match(owner string, k *DNSKEYRecord) bool {
+	if d.KeyTag != k.KeyTag() || d.Algorithm != k.Algorithm {
+		return false
+	}
+	x, err := k.DS(owner, d.DigestType)
+	return err == nil && bytes.Equal(x.Digest, d.Digest)
+}
+
+//RRSIGRecord the RRSIG record
+type RRSIGRecord struct {
+	TypeCovered Type
+	Algorithm   uint8
+	Labels      uint8
+	OriginalTTL uint32
+	// Expiration, Inception the seconds since 1970
+	Expiration uint32
+	Inception  uint32
+	KeyTag     uint16
+	SignerName string
+	Signature  []byte
+}
+
+//ParseRRSIG parse the rdata of the RRSIG record
+func ParseRRSIG(data []byte) (*RRSIGRecord, error) {
+	if len(data) < 19 {
+		return nil, errRecord
+	}
+	s := &RRSIGRecord{
+		TypeCovered: Type(binary.BigEndian.Uint16(data)),
+		Algorithm:   data[2],
+		Labels:      data[3],
+		OriginalTTL: binary.BigEndian.Uint32(data[4:]),
+		Expiration:  binary.BigEndian.Uint32(data[8:]),
+		Inception:   binary.BigEndian.Uint32(data[12:]),
+		KeyTag:      binary.BigEndian.Uint16(data[16:]),
+	}
+	name, n, err := readName(data, 18)
+	if err != nil {
+		return nil, err
+	}
+	s.SignerName = canonicalName(name)
+	s.Signature = data[18+n:]
+	return s, nil
+}
+
+//Data the rdata of the record
+func (s *RRSIGRecord) Data() ([]byte, error) {
+	h, err := s.header()
+	if err != nil {
+		return nil, err
+	}
+	return append(h, s.Signature...), nil
+}
+
+//header the rdata without the signature
+func (s *RRSIGRecord) header() ([]byte, error) {
+	signer, err := packName(s.SignerName)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 18, 18+len(signer)+len(s.Signature))
+	binary.BigEndian.PutUint16(b, uint16(s.TypeCovered))
+	b[2], b[3] = s.Algorithm, s.Labels
+	binary.BigEndian.PutUint32(b[4:], s.OriginalTTL)
+	binary.BigEndian.PutUint32(b[8:], s.Expiration)
+	binary.BigEndian.PutUint32(b[12:], s.Inception)
+	binary.BigEndian.PutUint16(b[16:], s.KeyTag)
+	return append(b, signer...), nil
+}
+
+//ValidAt whether the time (seconds since 1970) is in the validity period, the serial number arithmetic is used
+func (s *RRSIGRecord) ValidAt(now uint32) bool {
+	return int32(now-s.Inception) >= 0 && int32(s.Expiration-now) >= 0
+}
+
+//SignedData the data to sign of the rrset, https://tools.ietf.org/html/rfc4034#section-3.1.8.1
+func (s *RRSIGRecord) SignedData(rrset []dnsmessage.Resource) ([]byte, error) {
+	h, err := s.header()
+	if err != nil {
+		return nil, err
+	}
+	b := bytes.NewBuffer(h)
+
+	rdatas := make([][]byte, 0, len(rrset))
+	for _, r := range rrset {
+		data, err := canonicalRData(r)
+		if err != nil {
+			return nil, err
+		}
+		rdatas = append(rdatas, data)
+	}
+	sort.Slice(rdatas, func(i, j int) bool { return bytes.Compare(rdatas[i], rdatas[j]) < 0 })
+
+	if len(rrset) == 0 {
+		return b.Bytes(), nil
+	}
+	owner, err := packName(s.owner(rrset[0].Header.Name.String()))
+	if err != nil {
+		return nil, err
+	}
+	for i, data := range rdatas {
+		if i > 0 && bytes.Equal(data, rdatas[i-1]) {
+			continue
+		}
+		var x [10]byte
+		binary.BigEndian.PutUint16(x[:], uint16(s.TypeCovered))
+		binary.BigEndian.PutUint16(x[2:], uint16(rrset[0].Header.Class))
+		binary.BigEndian.PutUint32(x[4:], s.OriginalTTL)
+		binary.BigEndian.PutUint16(x[8:], uint16(len(data)))
+		b.Write(owner)
+		b.Write(x[:])
+		b.Write(data)
+	}
+	return b.Bytes(), nil
+}
+
+//owner the owner of the signed rrset, the wildcard name if the rrset is expanded from a wildcard
+func (s *RRSIGRecord) owner(name string) string {
+	labels := splitLabels(name)
+	if len(labels) > 0 && labels[0] == "*" {
+		labels = labels[1:]
+	}
+	if int(s.Labels) >= len(labels) {
+		return canonicalName(name)
+	}
+	return canonicalName("*." + strings.Join(labels[len(labels)-int(s.Labels):], "."))
+}
+
+//Verify verify the signature of the rrset by the key, the validity period is not checked
+func (s *RRSIGRecord) Verify(key *DNSKEYRecord, rrset []dnsmessage.Resource) error {
+	if key.Algorithm != s.Algorithm || key.KeyTag() != s.KeyTag {
+		return errors.New("the key doesn't match the signature")
+	}
+	data, err := s.SignedData(rrset)
+	if err != nil {
+		return err
+	}
+	return verify(s.Algorithm, key.PublicKey, data, s.Signature)
+}
+
+func verify(alg uint8, key, data, sig []byte) error {
+	switch alg {
+	case RSASHA1, RSASHA1NSEC3SHA1, RSASHA256, RSASHA512:
+		pub, err := rsaPublicKey(key)
+		if err != nil {
+			return err
+		}
+		var h crypto.Hash
+		var digest []byte
+		switch alg {
+		case RSASHA256:
+			x := sha256.Sum256(data)
+			h, digest = crypto.SHA256, x[:]
+		case RSASHA512:
+			x := sha512.Sum512(data)
+			h, digest = crypto.SHA512, x[:]
+		default:
+			x := sha1.Sum(data)
+			h, digest = crypto.SHA1, x[:]
+		}
+		return rsa.VerifyPKCS1v15(pub, h, digest, sig)
+
+	case ECDSAP256SHA256, ECDSAP384SHA384:
+		curve, digest := elliptic.P256(), sha256Sum(data)
+		if alg == ECDSAP384SHA384 {
+			x := sha512.Sum384(data)
+			curve, digest = elliptic.P384(), x[:]
+		}
+		size := curve.Params().BitSize / 8
+		if len(key) != 2*size || len(sig) != 2*size {
+			return errRecord
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(key[:size]),
+			Y:     new(big.Int).SetBytes(key[size:]),
+		}
+		if !ecdsa.Verify(pub, digest, new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])) {
+			return errors.New("ecdsa verification failed")
+		}
+		return nil
+
+	case ED25519:
+		if len(key) != ed25519.PublicKeySize {
+			return errRecord
+		}
+		if !ed25519.Verify(key, data, sig) {
+			return errors.New("ed25519 verification failed")
+		}
+		return nil
+	}
+	return ErrUnsupportedAlgorithm
+}
+
+func sha256Sum(data []byte) []byte {
+	x := sha256.Sum256(data)
+	return x[:]
+}
+
+//rsaPublicKey https://tools.ietf.org/html/rfc3110#section-2
+func rsaPublicKey(key []byte) (*rsa.PublicKey, error) {
+	if len(key) < 3 {
+		return nil, errRecord
+	}
+	l, off := int(key[0]), 1
+	if l == 0 {
+		l, off = int(binary.BigEndian.Uint16(key[1:])), 3
+	}
+	if l == 0 || l > 4 || off+l >= len(key) {
+		return nil, errors.New("unsupported rsa exponent")
+	}
+	var e int
+	for _, b := range key[off : off+l] {
+		e = e<<8 | int(b)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(key[off+l:]), E: e}, nil
+}
+
+//NSECRecord the NSEC record
+type NSECRecord struct {
+	NextDomain string
+	Types      []Type
+}
+
+//ParseNSEC parse the rdata of the NSEC record
+func ParseNSEC(data []byte) (*NSECRecord, error) {
+	name, n, err := readName(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	types, err := parseTypeBitmap(data[n:])
+	if err != nil {
+		return nil, err
+	}
+	return &NSECRecord{NextDomain: canonicalName(name), Types: types}, nil
+}
+
+//NSEC3Record the NSEC3 record, https://tools.ietf.org/html/rfc5155
+type NSEC3Record struct {
+	HashAlgorithm uint8
+	// Flags 1: opt-out
+	Flags      uint8
+	Iterations uint16
+	Salt       []byte
+	NextHashed []byte
+	Types      []Type
+}
+
+//ParseNSEC3 parse the rdata of the NSEC3 record
+func ParseNSEC3(data []byte) (*NSEC3Record, error) {
+	if len(data) < 5 {
+		return nil, errRecord
+	}
+	r := &NSEC3Record{HashAlgorithm: data[0], Flags: data[1], Iterations: binary.BigEndian.Uint16(data[2:])}
+	i := 5 + int(data[4])
+	if i >= len(data) {
+		return nil, errRecord
+	}
+	r.Salt = data[5:i]
+	j := i + 1 + int(data[i])
+	if j > len(data) {
+		return nil, errRecord
+	}
+	r.NextHashed = data[i+1 : j]
+	types, err := parseTypeBitmap(data[j:])
+	if err != nil {
+		return nil, err
+	}
+	r.Types = types
+	return r, nil
+}
+
+//OptOut the opt-out flag
+func (r *NSEC3Record) OptOut() bool { return r.Flags&1 != 0 }
+
+//Hash the hashed owner name of name, https://tools.ietf.org/html/rfc5155#section-5
+func (r *NSEC3Record) Hash(name string) ([]byte, error) {
+	if r.HashAlgorithm != 1 {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	x, err := packName(name)
+	if err != nil {
+		return nil, err
+	}
+	h := sha1.Sum(append(x, r.Salt...))
+	for i := 0; i < int(r.Iterations); i++ {
+		h = sha1.Sum(append(h[:], r.Salt...))
+	}
+	return h[:], nil
+}
+
+//nsec3Encoding the encoding of the hashed owner label
+var nsec3Encoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+func hasType(types []Type, t Type) bool {
+	for _, x := range types {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTypeBitmap(data []byte) ([]Type, error) {
+	var types []Type
+	for len(data) > 0 {
+		if len(data) < 2 || int(data[1]) > 32 || len(data) < 2+int(data[1]) {
+			return nil, errRecord
+		}
+		window, l := int(data[0]), int(data[1])
+		for i, b := range data[2 : 2+l] {
+			for j := 0; j < 8; j++ {
+				if b&(0x80>>j) != 0 {
+					types = append(types, Type(window<<8|i<<3|j))
+				}
+			}
+		}
+		data = data[2+l:]
+	}
+	return types, nil
+}
+
+//canonicalName the lower-case fully qualified name
+func canonicalName(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+func splitLabels(name string) []string {
+	name = strings.TrimSuffix(canonicalName(name), ".")
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, ".")
+}
+
+//parentName the parent of name, the parent of root is root
+func parentName(name string) string {
+	labels := splitLabels(name)
+	if len(labels) <= 1 {
+		return "."
+	}
+	return strings.Join(labels[1:], ".") + "."
+}
+
+//isSubdomain whether the name is the zone or under the zone
+func isSubdomain(name, zone string) bool {
+	name, zone = canonicalName(name), canonicalName(zone)
+	return zone == "." || name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+//canonicalCompare the canonical order of names, https://tools.ietf.org/html/rfc4034#section-6.1
+func canonicalCompare(a, b string) int {
+	x, y := splitLabels(a), splitLabels(b)
+	for i, j := len(x)-1, len(y)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := strings.Compare(x[i], y[j]); c != 0 {
+			return c
+		}
+	}
+	return len(x) - len(y)
+}
+
+//packName the uncompressed lower-case wire format of the name
+func packName(name string) ([]byte, error) {
+	var b []byte
+	for _, l := range splitLabels(name) {
+		if l == "" || len(l) > 63 {
+			return nil, errName
+		}
+		b = append(b, byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, 0), nil
+}
+
+func lowerName(n dnsmessage.Name) dnsmessage.Name {
+	x, err := dnsmessage.NewName(canonicalName(n.String()))
+	if err != nil {
+		return n
+	}
+	return x
+}
+
+//canonicalRData the rdata of the record with the lower-case uncompressed names
+func canonicalRData(r dnsmessage.Resource) ([]byte, error) {
+	body := r.Body
+	switch b := body.(type) {
+	case *dnsmessage.CNAMEResource:
+		body = &dnsmessage.CNAMEResource{CNAME: lowerName(b.CNAME)}
+	case *dnsmessage.NSResource:
+		body = &dnsmessage.NSResource{NS: lowerName(b.NS)}
+	case *dnsmessage.PTRResource:
+		body = &dnsmessage.PTRResource{PTR: lowerName(b.PTR)}
+	case *dnsmessage.MXResource:
+		body = &dnsmessage.MXResource{Pref: b.Pref, MX: lowerName(b.MX)}
+	case *dnsmessage.SRVResource:
+		x := *b
+		x.Target = lowerName(b.Target)
+		body = &x
+	case *dnsmessage.SOAResource:
+		x := *b
+		x.NS, x.MBox = lowerName(b.NS), lowerName(b.MBox)
+		body = &x
+	}
+
+	// the root owner name is 1 byte, the names in rdata can't be compressed by it
+	msg := dnsmessage.Message{Answers: []dnsmessage.Resource{{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("."), Type: r.Header.Type, Class: r.Header.Class},
+		Body:   body,
+	}}}
+	b, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack %v failed: %v", r.Header, err)
+	}
+	return b[12+1+10:], nil
 }
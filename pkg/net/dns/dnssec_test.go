@@ -1 +1,310 @@
 package dns
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//testSigner sign the rrsets of the zone
+type testSigner struct {
+	zone string
+	key  *DNSKEYRecord
+	sign func([]byte) []byte
+}
+
+func newECDSASigner(zone string) *testSigner {
+	k, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pub := append(pad(k.X.Bytes(), 32), pad(k.Y.Bytes(), 32)...)
+	return &testSigner{
+		zone: zone,
+		key:  &DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: ECDSAP256SHA256, PublicKey: pub},
+		sign: func(data []byte) []byte {
+			h := sha256.Sum256(data)
+			r, s, _ := ecdsa.Sign(rand.Reader, k, h[:])
+			return append(pad(r.Bytes(), 32), pad(s.Bytes(), 32)...)
+		},
+	}
+}
+
+func newEd25519Signer(zone string) *testSigner {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	return &testSigner{
+		zone: zone,
+		key:  &DNSKEYRecord{Flags: 257, Protocol: 3, Algorithm: ED25519, PublicKey: pub},
+		sign: func(data []byte) []byte { return ed25519.Sign(priv, data) },
+	}
+}
+
+func pad(b []byte, n int) []byte {
+	return append(make([]byte, n-len(b)), b...)
+}
+
+//testZone the records of a fake signed hierarchy, answer the queries like a non-validating resolver
+type testZone struct {
+	DNS
+	records []dnsmessage.Resource
+}
+
+func resource(name string, t Type, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: t, Class: dnsmessage.ClassINET, TTL: 300},
+		Body:   body,
+	}
+}
+
+func unknown(name string, t Type, data []byte) dnsmessage.Resource {
+	return resource(name, t, &dnsmessage.UnknownResource{Type: t, Data: data})
+}
+
+//sign add the rrset and the signature
+func (z *testZone) sign(s *testSigner, rrset ...dnsmessage.Resource) {
+	now := uint32(time.Now().Unix())
+	name := rrset[0].Header.Name.String()
+	sig := &RRSIGRecord{
+		TypeCovered: rrset[0].Header.Type,
+		Algorithm:   s.key.Algorithm,
+		Labels:      uint8(len(splitLabels(name))),
+		OriginalTTL: 300,
+		Expiration:  now + 3600,
+		Inception:   now - 3600,
+		KeyTag:      s.key.KeyTag(),
+		SignerName:  s.zone,
+	}
+	data, err := sig.SignedData(rrset)
+	if err != nil {
+		panic(err)
+	}
+	sig.Signature = s.sign(data)
+	rdata, _ := sig.Data()
+	z.records = append(z.records, rrset...)
+	z.records = append(z.records, unknown(name, RRSIG, rdata))
+}
+
+func (z *testZone) nsec(s *testSigner, name, next string, types ...Type) {
+	b, _ := packName(next)
+	z.sign(s, unknown(name, NSEC, append(b, typeBitmap(types...)...)))
+}
+
+func typeBitmap(types ...Type) []byte {
+	b := make([]byte, 32)
+	l := 0
+	for _, t := range types {
+		b[t/8] |= 0x80 >> (t % 8)
+		if int(t/8)+1 > l {
+			l = int(t/8) + 1
+		}
+	}
+	return append([]byte{0, byte(l)}, b[:l]...)
+}
+
+func (z *testZone) match(name string, t Type) []dnsmessage.Resource {
+	var rs []dnsmessage.Resource
+	for _, r := range z.records {
+		if canonicalName(r.Header.Name.String()) != name {
+			continue
+		}
+		if r.Header.Type == t {
+			rs = append(rs, r)
+		}
+		if r.Header.Type == RRSIG {
+			if s, _ := ParseRRSIG(r.Body.(*dnsmessage.UnknownResource).Data); s.TypeCovered == t {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return rs
+}
+
+func (z *testZone) Do(req []byte) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, err
+	}
+	msg.Response, msg.Additionals = true, nil
+	q := msg.Questions[0]
+	name := canonicalName(q.Name.String())
+
+	if msg.Answers = z.match(name, q.Type); len(msg.Answers) == 0 {
+		if msg.Authorities = z.match(name, NSEC); len(msg.Authorities) == 0 {
+			msg.RCode = dnsmessage.RCodeNameError
+			for _, r := range z.records {
+				if r.Header.Type != NSEC {
+					continue
+				}
+				x, _ := ParseNSEC(r.Body.(*dnsmessage.UnknownResource).Data)
+				n := nsec{canonicalName(r.Header.Name.String()), "example.", x}
+				if n.covers(name) || n.covers("*."+parentName(name)) {
+					msg.Authorities = append(msg.Authorities, z.match(n.owner, NSEC)...)
+				}
+			}
+		}
+	}
+	return msg.Pack()
+}
+
+//newTestZone . signed by ecdsa, example. signed by ed25519, insecure.example. isn't signed
+func newTestZone(t *testing.T) (*testZone, string) {
+	root, example := newECDSASigner("."), newEd25519Signer("example.")
+	z := &testZone{}
+
+	z.sign(root, unknown(".", DNSKEY, root.key.Data()))
+	ds, err := example.key.DS("example.", DigestSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z.sign(root, unknown("example.", DS, dsData(ds)))
+
+	z.sign(example, unknown("example.", DNSKEY, example.key.Data()))
+	z.sign(example,
+		resource("www.example.", A, &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}}),
+		resource("www.example.", A, &dnsmessage.AResource{A: [4]byte{1, 1, 1, 1}}))
+	z.records = append(z.records, resource("nosig.example.", A, &dnsmessage.AResource{A: [4]byte{7, 7, 7, 7}}))
+	z.records = append(z.records, resource("www.insecure.example.", A, &dnsmessage.AResource{A: [4]byte{5, 6, 7, 8}}))
+
+	// the signed data of bad.example. is different from the answer
+	z.sign(example, resource("bad.example.", A, &dnsmessage.AResource{A: [4]byte{1, 1, 1, 1}}))
+	for i := range z.records {
+		if z.records[i].Header.Name.String() == "bad.example." && z.records[i].Header.Type == A {
+			z.records[i].Body = &dnsmessage.AResource{A: [4]byte{6, 6, 6, 6}}
+		}
+	}
+
+	z.nsec(example, "example.", "bad.example.", NS, SOA, RRSIG, NSEC, DNSKEY)
+	z.nsec(example, "bad.example.", "insecure.example.", A, RRSIG, NSEC)
+	z.nsec(example, "insecure.example.", "nosig.example.", NS, NSEC, RRSIG)
+	z.nsec(example, "nosig.example.", "www.example.", A, NSEC, RRSIG)
+	z.nsec(example, "www.example.", "example.", A, NSEC, RRSIG)
+
+	anchor, _ := root.key.DS(".", DigestSHA256)
+	return z, fmt.Sprintf(". IN DS %d %d %d %X", anchor.KeyTag, anchor.Algorithm, anchor.DigestType, anchor.Digest)
+}
+
+func dsData(d *DSRecord) []byte {
+	return append([]byte{byte(d.KeyTag >> 8), byte(d.KeyTag), d.Algorithm, d.DigestType}, d.Digest...)
+}
+
+func TestValidator(t *testing.T) {
+	z, anchor := newTestZone(t)
+	v, err := NewValidator(z.Do, anchor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		t    Type
+		want Security
+	}{
+		{"www.example", A, Secure},
+		{"www.example", AAAA, Secure},
+		{"nx.example", A, Secure},
+		{"www.insecure.example", A, Insecure},
+		{"bad.example", A, Bogus},
+		{"nosig.example", A, Bogus},
+		{"www.example.org", A, Bogus},
+	} {
+		req, _ := NewDNSSECRequest(c.name, c.t)
+		resp, err := z.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sec, err := v.Validate(req, resp)
+		t.Log(c.name, c.t, sec, err)
+		if sec != c.want {
+			t.Errorf("%s %v: want %v, got %v", c.name, c.t, c.want, sec)
+		}
+	}
+}
+
+func TestValidatorDenial(t *testing.T) {
+	z, anchor := newTestZone(t)
+	v, _ := NewValidator(z.Do, anchor)
+
+	req, _ := NewDNSSECRequest("nx.example", A)
+	resp, _ := z.Do(req)
+	var msg dnsmessage.Message
+	_ = msg.Unpack(resp)
+
+	// without the nsec of the wildcard
+	for i := range msg.Authorities {
+		if msg.Authorities[i].Header.Name.String() == "example." {
+			msg.Authorities = append(msg.Authorities[:i], msg.Authorities[i+2:]...)
+			break
+		}
+	}
+	resp, _ = msg.Pack()
+	if sec, err := v.Validate(req, resp); sec != Bogus {
+		t.Errorf("want bogus, got %v %v", sec, err)
+	}
+
+	// the expired keys are fetched again
+	v.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	req, _ = NewDNSSECRequest("www.example", A)
+	resp, _ = z.Do(req)
+	if sec, err := v.Validate(req, resp); sec != Bogus {
+		t.Errorf("want bogus for the expired signatures, got %v %v", sec, err)
+	}
+}
+
+func TestDNSSEC(t *testing.T) {
+	z, anchor := newTestZone(t)
+	d, err := NewDNSSEC(z, nil, []string{anchor}, WithStrategy(utils.IPv4Only))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ips, err := d.LookupIP("www.example")
+	if err != nil || len(ips) != 2 {
+		t.Errorf("want the secure answer, got %v %v", ips, err)
+	}
+	ips, err = d.LookupIP("www.insecure.example")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(5, 6, 7, 8)) {
+		t.Errorf("want the insecure answer, got %v %v", ips, err)
+	}
+	if _, err = d.LookupIP("bad.example"); !errors.Is(err, ErrBogus) {
+		t.Errorf("want ErrBogus, got %v", err)
+	}
+
+	req, _ := NewRequest("www.example", A)
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[3]&adBit == 0 {
+		t.Error("want the ad bit of the secure answer")
+	}
+	var msg dnsmessage.Message
+	_ = msg.Unpack(resp)
+	if len(msg.Answers) != 2 {
+		t.Errorf("want the rrsig removed, got %v", msg.Answers)
+	}
+}
+
+func TestDS(t *testing.T) {
+	s := newEd25519Signer("example.")
+	ds, _ := s.key.DS("Example", DigestSHA256)
+	owner, x, err := ParseDSText(fmt.Sprintf("example. 3600 IN DS %d %d %d %X", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != "example." || !x.Match("example.", s.key) {
+		t.Errorf("ds doesn't match: %s %+v", owner, x)
+	}
+	if x.Match("other.", s.key) {
+		t.Error("ds of example. matches other.")
+	}
+	if _, _, err = ParseDSText("example. IN DS 1 2"); err == nil {
+		t.Error("want error for the invalid ds")
+	}
+}
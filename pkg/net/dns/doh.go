@@ -29,17 +29,17 @@ type doh struct {
 	port string
 	url  string
 
-	cache      *utils.LRU
 	httpClient *http.Client
+	option
 }
 
-func NewDoH(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
+func NewDoH(host string, subnet *net.IPNet, p proxy.Proxy, opts ...Option) DNS {
 	if subnet == nil {
 		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
 	}
 	dns := &doh{
 		Subnet: subnet,
-		cache:  utils.NewLru(200, 20*time.Minute),
+		option: newOption(opts),
 	}
 
 	dns.setServer(host)
@@ -57,22 +57,14 @@ func NewDoH(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
 
 // LookupIP .
 // https://tools.ietf.org/html/rfc8484
-func (d *doh) LookupIP(domain string) (ip []net.IP, err error) {
-	if x, _ := d.cache.Load(domain); x != nil {
-		return x.([]net.IP), nil
-	}
-	if ip, err = d.search(domain); len(ip) != 0 {
-		d.cache.Add(domain, ip)
-	}
-	return
-}
-
-func (d *doh) search(domain string) ([]net.IP, error) {
-	DNS, err := dnsHandle(domain, d.Subnet, d.post)
-	if err != nil || len(DNS) == 0 {
-		return nil, fmt.Errorf("doh resolve domain %s failed: %v", domain, err)
+func (d *doh) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := d.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, d.Subnet, d.strategy, d.post)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("doh resolve domain %s failed: %w", domain, err)
 	}
-	return DNS, nil
+	return ips, nil
 }
 
 func (d *doh) setServer(host string) {
@@ -93,6 +85,8 @@ func (d *doh) setServer(host string) {
 	}
 
 	d.ClientUtil = utils.NewClientUtil(d.host, d.port)
+	// the dns may be the bootstrap, resolve itself by the system resolver
+	d.ClientUtil.SetLookup(utils.SystemLookup)
 }
 
 func (d *doh) setProxy(p func(string) (net.Conn, error)) {
@@ -143,6 +137,10 @@ func (d *doh) post(dReq []byte) (body []byte, err error) {
 	return
 }
 
+func (d *doh) Do(req []byte) ([]byte, error) {
+	return d.hostsDo(req, d.post)
+}
+
 func (d *doh) Resolver() *net.Resolver {
 	return &net.Resolver{
 		PreferGo: true,
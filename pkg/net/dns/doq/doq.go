@@ -0,0 +1,147 @@
+package doq
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/lucas-clemente/quic-go"
+)
+
+//doqProtos the ALPN of dns over quic, "doq" is RFC 9250, the drafts don't prefix the message with the length
+var doqProtos = []string{"doq", "doq-i02", "doq-i00"}
+
+//doq dns over quic, every query uses a new stream of the shared session
+//https://datatracker.ietf.org/doc/html/rfc9250
+type doq struct {
+	host       string
+	servername string
+	proxy      proxy.Proxy
+	tlsConfig  *tls.Config
+
+	session quic.Session
+	conn    net.PacketConn
+	lock    sync.Mutex
+}
+
+//New the dns over quic client, it's out of the package dns to keep the quic stack away from the others,
+//the default port is 853
+func New(host string, subnet *net.IPNet, p proxy.Proxy, opts ...dns.Option) dns.DNS {
+	return dns.NewExchange("doq", subnet, newDoQ(host, p).exchange, opts...)
+}
+
+func newDoQ(host string, p proxy.Proxy) *doq {
+	if p == nil {
+		p = &proxy.DefaultProxy{}
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "853")
+	}
+	servername, _, _ := net.SplitHostPort(host)
+	return &doq{
+		host:       host,
+		servername: servername,
+		proxy:      p,
+		tlsConfig: &tls.Config{
+			ServerName:         servername,
+			NextProtos:         doqProtos,
+			ClientSessionCache: tls.NewLRUClientSessionCache(0),
+		},
+	}
+}
+
+//getSession reuse the session, dial a new one if it is closed
+func (d *doq) getSession(ctx context.Context) (quic.Session, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.session != nil {
+		select {
+		case <-d.session.Context().Done():
+			_ = d.conn.Close()
+			d.session = nil
+		default:
+			return d.session, nil
+		}
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", d.host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve addr failed: %v", err)
+	}
+	conn, err := d.proxy.PacketConn(d.host)
+	if err != nil {
+		return nil, fmt.Errorf("get packetConn failed: %v", err)
+	}
+
+	session, err := quic.DialContext(ctx, conn, addr, d.servername, d.tlsConfig, &quic.Config{
+		HandshakeTimeout: 5 * time.Second,
+		MaxIdleTimeout:   30 * time.Second,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("quic dial failed: %v", err)
+	}
+
+	d.session, d.conn = session, conn
+	return session, nil
+}
+
+func (d *doq) exchange(req []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+
+	session, err := d.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := session.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open stream failed: %v", err)
+	}
+	_ = stream.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// the message id must be 0
+	id := binary.BigEndian.Uint16(req)
+	msg := append([]byte{0, 0}, req[2:]...)
+
+	rfc := session.ConnectionState().NegotiatedProtocol == "doq"
+	if rfc {
+		msg = append([]byte{byte(len(msg) >> 8), byte(len(msg))}, msg...)
+	}
+	if _, err = stream.Write(msg); err != nil {
+		return nil, fmt.Errorf("write data failed: %v", err)
+	}
+	// the client must send the STREAM FIN after the query
+	_ = stream.Close()
+
+	var resp []byte
+	if rfc {
+		length := make([]byte, 2)
+		if _, err = io.ReadFull(stream, length); err != nil {
+			return nil, fmt.Errorf("read data length from server failed: %v", err)
+		}
+		resp = make([]byte, binary.BigEndian.Uint16(length))
+		_, err = io.ReadFull(stream, resp)
+	} else {
+		resp, err = ioutil.ReadAll(stream)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read data from server failed: %v", err)
+	}
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("invalid response length: %d", len(resp))
+	}
+
+	binary.BigEndian.PutUint16(resp, id)
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package doq
+
+import (
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
+	"github.com/lucas-clemente/quic-go"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//answer answer the A query with ip, the others are answered without the records
+func answer(req []byte, ip net.IP) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, err
+	}
+	msg.Response = true
+	msg.Additionals = nil
+	q := msg.Questions[0]
+	if q.Type == dnsmessage.TypeA {
+		a := &dnsmessage.AResource{}
+		copy(a.A[:], ip.To4())
+		msg.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60},
+			Body:   a,
+		}}
+	}
+	return msg.Pack()
+}
+
+//testDoQServer a dns over quic server answering the A queries with ip, proto: the alpn of the server,
+//the count of the sessions is returned
+func testDoQServer(t *testing.T, proto string, ip net.IP) (string, *int32) {
+	config := proxytest.TLSConfig(t, "doq.test")
+	config.NextProtos = []string{proto}
+	lis, err := quic.ListenAddr("127.0.0.1:0", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	var sessions int32
+	go func() {
+		for {
+			s, err := lis.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&sessions, 1)
+			go func() {
+				for {
+					stream, err := s.AcceptStream(context.Background())
+					if err != nil {
+						return
+					}
+					go func() {
+						defer stream.Close()
+						req, err := ioutil.ReadAll(stream)
+						if err != nil {
+							return
+						}
+						if proto == "doq" {
+							if len(req) < 2 || int(binary.BigEndian.Uint16(req)) != len(req)-2 {
+								t.Errorf("invalid length of the query: %v", req)
+								return
+							}
+							req = req[2:]
+						}
+						if len(req) < 2 || binary.BigEndian.Uint16(req) != 0 {
+							t.Errorf("the message id must be 0: %v", req)
+							return
+						}
+						resp, err := answer(req, ip)
+						if err != nil {
+							return
+						}
+						if proto == "doq" {
+							resp = append([]byte{byte(len(resp) >> 8), byte(len(resp))}, resp...)
+						}
+						_, _ = stream.Write(resp)
+					}()
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String(), &sessions
+}
+
+func TestDoQ(t *testing.T) {
+	for _, proto := range []string{"doq", "doq-i02"} {
+		addr, sessions := testDoQServer(t, proto, net.IP{1, 2, 3, 4})
+
+		q := newDoQ(addr, nil)
+		q.tlsConfig.ServerName = "doq.test"
+		q.tlsConfig.InsecureSkipVerify = true
+		d := dns.NewExchange("doq", nil, q.exchange)
+
+		for _, domain := range []string{"www.example.com", "www.example.org"} {
+			ips, err := d.LookupIP(domain)
+			if err != nil {
+				t.Fatalf("%s: %v", proto, err)
+			}
+			if len(ips) != 1 || !ips[0].Equal(net.IP{1, 2, 3, 4}) {
+				t.Errorf("%s: want 1.2.3.4, got %v", proto, ips)
+			}
+		}
+
+		// the session is reused by the queries
+		if x := atomic.LoadInt32(sessions); x != 1 {
+			t.Errorf("%s: want 1 session, got %d", proto, x)
+		}
+	}
+}
+
+func TestDoQVerify(t *testing.T) {
+	addr, _ := testDoQServer(t, "doq", net.IP{1, 2, 3, 4})
+
+	// the certificate isn't trusted
+	if _, err := New(addr, nil, nil).LookupIP("www.example.com"); err == nil {
+		t.Error("want the certificate verify error")
+	}
+}
@@ -17,15 +17,17 @@ type dot struct {
 	subnet       *net.IPNet
 	proxy        func(string) (net.Conn, error)
 	sessionCache tls.ClientSessionCache
+	option
 }
 
-func NewDoT(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
+func NewDoT(host string, subnet *net.IPNet, p proxy.Proxy, opts ...Option) DNS {
 	if subnet == nil {
 		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
 	}
 	if p == nil {
 		p = &proxy.DefaultProxy{}
 	}
+	host = withPort(host, "853")
 	servername, _, _ := net.SplitHostPort(host)
 	return &dot{
 		host:         host,
@@ -33,10 +35,25 @@ func NewDoT(host string, subnet *net.IPNet, p proxy.Proxy) DNS {
 		servername:   servername,
 		sessionCache: tls.NewLRUClientSessionCache(0),
 		proxy:        p.Conn,
+		option:       newOption(opts),
 	}
 }
 
 func (d *dot) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := d.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, d.subnet, d.strategy, d.exchange)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dot resolve domain %s failed: %w", domain, err)
+	}
+	return ips, nil
+}
+
+func (d *dot) Do(req []byte) ([]byte, error) {
+	return d.hostsDo(req, d.exchange)
+}
+
+func (d *dot) exchange(req []byte) ([]byte, error) {
 	conn, err := d.proxy(d.host)
 	if err != nil {
 		return nil, fmt.Errorf("tcp dial failed: %v", err)
@@ -46,26 +63,7 @@ func (d *dot) LookupIP(domain string) ([]net.IP, error) {
 		ClientSessionCache: d.sessionCache,
 	})
 	defer conn.Close()
-	return dnsHandle(domain, d.subnet, func(reqData []byte) (body []byte, err error) {
-		length := len(reqData) // dns over tcp, prefix two bytes is request data's length
-		reqData = append([]byte{byte(length >> 8), byte(length - ((length >> 8) << 8))}, reqData...)
-		_, err = conn.Write(reqData)
-		if err != nil {
-			return nil, fmt.Errorf("write data failed: %v", err)
-		}
-
-		leg := make([]byte, 2)
-		_, err = conn.Read(leg)
-		if err != nil {
-			return nil, fmt.Errorf("read data length from server failed %v", err)
-		}
-		all := make([]byte, int(leg[0])<<8+int(leg[1]))
-		n, err := conn.Read(all)
-		if err != nil {
-			return nil, fmt.Errorf("read data from server failed: %v", err)
-		}
-		return all[:n], err
-	})
+	return tcpDo(conn, req)
 }
 
 func (d *dot) Resolver() *net.Resolver {
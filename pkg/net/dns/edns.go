@@ -1,8 +1,12 @@
 package dns
 
 import (
-	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"net"
+
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 type EDNSOPT [2]byte
@@ -30,98 +34,94 @@ var (
 
 )
 
-type eDNSHeader struct {
-	DnsHeader   []byte
-	Name        [1]byte
-	Type        [2]byte
-	PayloadSize [2]byte
-	ExtendRCode [1]byte
-	EDNSVersion [1]byte
-	Z           [2]byte
-	Data        []byte
-}
+//Code the option code
+func (e EDNSOPT) Code() uint16 { return binary.BigEndian.Uint16(e[:]) }
 
-func createEDNSRequ(header eDNSHeader) []byte {
-	header.DnsHeader[10] = 0b00000000
-	header.DnsHeader[11] = 0b00000001
-	length := getLength(len(header.Data))
-	return bytes.Join([][]byte{header.DnsHeader, header.Name[:], header.Type[:], header.PayloadSize[:], header.ExtendRCode[:], header.EDNSVersion[:], header.Z[:], length[:], header.Data}, []byte{})
+//EDNS the OPT record, https://tools.ietf.org/html/rfc6891
+type EDNS struct {
+	UDPSize uint16
+	// ExtendedRCode the upper 8 bits of the response code
+	ExtendedRCode uint8
+	Version       uint8
+	// DNSSECOK the DO bit
+	DNSSECOK bool
+	Options  []dnsmessage.Option
 }
 
-// https://tools.ietf.org/html/rfc7871
-// https://tools.ietf.org/html/rfc2671
-func createEDNSReq(domain string, reqType2 reqType, eDNS []byte) []byte {
-	data := bytes.NewBuffer(creatRequest(domain, reqType2, true))
-	data.WriteByte(0b00000000)                 // name
-	data.Write([]byte{0b00000000, 0b00101001}) // type 41
-	data.Write([]byte{0b00010000, 0b00000000}) // payloadSize 4096
-	data.WriteByte(0b00000000)                 // extendRcode
-	data.WriteByte(0b00000000)                 // EDNS Version
-	data.Write([]byte{0b00000000, 0b00000000}) // Z
-	data.Write(getLength(len(eDNS)))           // data length
-	data.Write(eDNS)                           // data
-	return data.Bytes()
+//ParseEDNS parse the OPT record, nil if it is not an OPT record
+func ParseEDNS(r dnsmessage.Resource) *EDNS {
+	if r.Header.Type != OPT {
+		return nil
+	}
+	e := &EDNS{
+		UDPSize:       uint16(r.Header.Class),
+		ExtendedRCode: uint8(r.Header.TTL >> 24),
+		Version:       uint8(r.Header.TTL >> 16),
+		DNSSECOK:      r.Header.DNSSECAllowed(),
+	}
+	if o, ok := r.Body.(*dnsmessage.OPTResource); ok {
+		e.Options = o.Options
+	}
+	return e
 }
 
-func createEdnsClientSubnet(ip *net.IPNet) []byte {
-	optionData := bytes.NewBuffer(nil)
-	mask, _ := ip.Mask.Size()
-	subnet := ip.IP.To4()
-	if subnet == nil { // family https://www.iana.org/assignments/address-family-numbers/address-family-numbers.xhtml
-		optionData.Write([]byte{0b00000000, 0b00000010}) // family ipv6 2
-		subnet = ip.IP.To16()
-	} else {
-		optionData.Write([]byte{0b00000000, 0b00000001}) // family ipv4 1
+//Option get the data of the option code
+func (e *EDNS) Option(code EDNSOPT) ([]byte, bool) {
+	for _, o := range e.Options {
+		if o.Code == code.Code() {
+			return o.Data, true
+		}
 	}
-	optionData.WriteByte(byte(mask)) // mask
-	optionData.WriteByte(0b00000000) // 0 In queries, it MUST be set to 0.
-	optionData.Write(subnet)         // subnet IP
+	return nil, false
+}
 
-	data := bytes.NewBuffer(nil)
-	data.Write([]byte{EdnsClientSubnet[0], EdnsClientSubnet[1]}) // option Code
-	data.Write(getLength(optionData.Len()))                      // option data length
-	data.Write(optionData.Bytes())                               // option data
-	return data.Bytes()
+//ClientSubnet the edns client subnet option, https://tools.ietf.org/html/rfc7871
+type ClientSubnet struct {
+	IP           net.IP
+	SourcePrefix uint8
+	ScopePrefix  uint8
 }
 
-func getLength(length int) []byte {
-	return []byte{byte(length >> 8), byte(length - ((length >> 8) << 8))}
+//ClientSubnet parse the client subnet option, nil if no such option
+func (e *EDNS) ClientSubnet() (*ClientSubnet, error) {
+	data, ok := e.Option(EdnsClientSubnet)
+	if !ok {
+		return nil, nil
+	}
+	return ParseClientSubnet(data)
 }
 
-func resolveAdditional(b []byte, arCount int) {
-	for arCount != 0 {
-		arCount--
-		//name := b[:1]
-		b = b[1:] // name
-		typeE := b[:2]
-		b = b[2:] // type
-		b = b[2:] // payLoadSize
-		b = b[1:] // rCode
-		b = b[1:] // version
-		b = b[2:] // Z
-		dataLength := int(b[0])<<8 + int(b[1])
-		b = b[2:]
-		if typeE[0] != 0 || typeE[1] != 41 {
-			//optData := b[:dataLength]
-			b = b[dataLength:] // optData
-			continue
-		}
+//ParseClientSubnet parse the data of the client subnet option
+func ParseClientSubnet(data []byte) (*ClientSubnet, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid client subnet option")
+	}
+	c := &ClientSubnet{SourcePrefix: data[2], ScopePrefix: data[3]}
+	// family https://www.iana.org/assignments/address-family-numbers/address-family-numbers.xhtml
+	switch binary.BigEndian.Uint16(data) {
+	case 1:
+		c.IP = make(net.IP, net.IPv4len)
+	case 2:
+		c.IP = make(net.IP, net.IPv6len)
+	default:
+		return nil, fmt.Errorf("unknown address family: %d", binary.BigEndian.Uint16(data))
+	}
+	if len(data)-4 > len(c.IP) {
+		return nil, errors.New("invalid client subnet address")
+	}
+	copy(c.IP, data[4:])
+	return c, nil
+}
 
-		if dataLength == 0 {
-			continue
-		}
-		optCode := EDNSOPT{b[0], b[1]}
-		b = b[2:]
-		optionLength := int(b[0])<<8 + int(b[1])
-		b = b[2:]
-		switch optCode {
-		case EdnsClientSubnet:
-			b = b[2:]              // family
-			b = b[1:]              // source Netmask
-			b = b[1:]              // scope Netmask
-			b = b[optionLength-4:] // Subnet IP
-		default:
-			b = b[optionLength:] // opt data
-		}
+//clientSubnetOption create the client subnet option of the query
+func clientSubnetOption(ip *net.IPNet) dnsmessage.Option {
+	mask, _ := ip.Mask.Size()
+	family, subnet := []byte{0, 1}, ip.IP.To4()
+	if subnet == nil {
+		family, subnet = []byte{0, 2}, ip.IP.To16()
 	}
+	// the address is truncated to the prefix length, the scope MUST be 0 in queries
+	data := append(family, byte(mask), 0)
+	data = append(data, subnet[:(mask+7)/8]...)
+	return dnsmessage.Option{Code: EdnsClientSubnet.Code(), Data: data}
 }
@@ -0,0 +1,95 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+var _ DNS = (*exchangeDNS)(nil)
+
+//exchangeDNS the dns of the transports in the other packages, eg: dns over quic
+type exchangeDNS struct {
+	name     string
+	subnet   *net.IPNet
+	exchange func([]byte) ([]byte, error)
+	option
+}
+
+//NewExchange the DNS sending the requests by exchange, the hosts, cache and strategy of opts are applied,
+//so the transports needing the extra dependencies can live in their own packages
+func NewExchange(name string, subnet *net.IPNet, exchange func([]byte) ([]byte, error), opts ...Option) DNS {
+	if subnet == nil {
+		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
+	}
+	return &exchangeDNS{
+		name:     name,
+		subnet:   subnet,
+		exchange: exchange,
+		option:   newOption(opts),
+	}
+}
+
+func (e *exchangeDNS) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := e.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, e.subnet, e.strategy, e.exchange)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s resolve domain %s failed: %w", e.name, domain, err)
+	}
+	return ips, nil
+}
+
+func (e *exchangeDNS) Do(req []byte) ([]byte, error) {
+	return e.hostsDo(req, e.exchange)
+}
+
+func (e *exchangeDNS) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return &doConn{do: e.Do}, nil
+		},
+	}
+}
+
+var _ net.Conn = (*doConn)(nil)
+var _ net.PacketConn = (*doConn)(nil)
+
+//doConn the fake packet conn of net.Resolver, the request is sent by do when it is written
+type doConn struct {
+	do     func([]byte) ([]byte, error)
+	buffer bytes.Buffer
+}
+
+func (d *doConn) Write(b []byte) (int, error) {
+	resp, err := d.do(b)
+	if err != nil {
+		return 0, err
+	}
+	d.buffer.Write(resp)
+	return len(b), nil
+}
+
+func (d *doConn) Read(b []byte) (int, error) { return d.buffer.Read(b) }
+
+func (d *doConn) WriteTo(b []byte, _ net.Addr) (int, error) { return d.Write(b) }
+
+func (d *doConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, err := d.Read(b)
+	return n, nil, err
+}
+
+func (d *doConn) LocalAddr() net.Addr { return nil }
+
+func (d *doConn) RemoteAddr() net.Addr { return nil }
+
+func (d *doConn) Close() error { return nil }
+
+func (d *doConn) SetDeadline(time.Time) error { return nil }
+
+func (d *doConn) SetReadDeadline(time.Time) error { return nil }
+
+func (d *doConn) SetWriteDeadline(time.Time) error { return nil }
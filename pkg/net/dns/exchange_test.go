@@ -0,0 +1,28 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestExchange(t *testing.T) {
+	d := NewExchange("mock", nil, (&mockUpstream{ip: net.IP{1, 2, 3, 4}}).Do,
+		WithHosts(NewHosts(map[string]string{"hosts.example.com": "5.6.7.8"})))
+
+	ips, err := d.LookupIP("www.example.com")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IP{1, 2, 3, 4}) {
+		t.Errorf("want 1.2.3.4, got %v %v", ips, err)
+	}
+
+	// the hosts are answered before the exchange
+	ips, err = d.LookupIP("hosts.example.com")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IP{5, 6, 7, 8}) {
+		t.Errorf("want 5.6.7.8, got %v %v", ips, err)
+	}
+
+	ips, err = d.Resolver().LookupIP(context.Background(), "ip4", "www.example.com")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IP{1, 2, 3, 4}) {
+		t.Errorf("want 1.2.3.4 by the resolver, got %v %v", ips, err)
+	}
+}
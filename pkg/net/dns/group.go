@@ -0,0 +1,256 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+//Mode how the group uses the upstreams
+type Mode int32
+
+const (
+	//Sequential try the upstreams one by one until one answers
+	Sequential Mode = 0
+	//Race query all upstreams concurrently, use the first answer
+	Race Mode = 1
+	//Fallback use the answer of the first upstream if it is trusted, otherwise race the others
+	Fallback Mode = 2
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Race:
+		return "race"
+	case Fallback:
+		return "fallback"
+	default:
+		return "sequential"
+	}
+}
+
+//Upstream a dns server of the group, Health can be shared between the groups
+type Upstream struct {
+	Name   string
+	DNS    DNS
+	Health *Health
+}
+
+//Health the success/failure counters and the latency of an upstream
+type Health struct {
+	success uint64
+	failure uint64
+	latency int64
+	lastErr atomic.Value
+}
+
+//HealthStats the snapshot of Health
+type HealthStats struct {
+	Success uint64
+	Failure uint64
+	// Latency the latency of the last success query
+	Latency time.Duration
+	// Error the last error, empty if the last query succeeded
+	Error string
+}
+
+func (h *Health) record(latency time.Duration, err error) {
+	if err != nil {
+		atomic.AddUint64(&h.failure, 1)
+		h.lastErr.Store(err.Error())
+		return
+	}
+	atomic.AddUint64(&h.success, 1)
+	atomic.StoreInt64(&h.latency, int64(latency))
+	h.lastErr.Store("")
+}
+
+//Stats get the snapshot of the health
+func (h *Health) Stats() HealthStats {
+	s := HealthStats{
+		Success: atomic.LoadUint64(&h.success),
+		Failure: atomic.LoadUint64(&h.failure),
+		Latency: time.Duration(atomic.LoadInt64(&h.latency)),
+	}
+	s.Error, _ = h.lastErr.Load().(string)
+	return s
+}
+
+var _ DNS = (*Group)(nil)
+
+//Group the dns with multiple upstreams
+type Group struct {
+	mode      Mode
+	upstreams []Upstream
+	subnet    *net.IPNet
+	option
+}
+
+//WithTrust the answer of the first upstream is trusted if all ips are trusted, used by the fallback mode
+func WithTrust(f func(net.IP) bool) Option {
+	return func(o *option) { o.trust = f }
+}
+
+//NewGroup create the group, the upstreams without Health get a new one
+func NewGroup(mode Mode, subnet *net.IPNet, upstreams []Upstream, opts ...Option) *Group {
+	if subnet == nil {
+		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
+	}
+	for i := range upstreams {
+		if upstreams[i].Health == nil {
+			upstreams[i].Health = &Health{}
+		}
+	}
+	return &Group{
+		mode:      mode,
+		upstreams: upstreams,
+		subnet:    subnet,
+		option:    newOption(opts),
+	}
+}
+
+func (g *Group) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := g.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, g.subnet, g.strategy, g.exchange)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s group resolve domain %s failed: %w", g.mode, domain, err)
+	}
+	return ips, nil
+}
+
+func (g *Group) Do(req []byte) ([]byte, error) {
+	return g.hostsDo(req, g.exchange)
+}
+
+func (g *Group) exchange(req []byte) ([]byte, error) {
+	if len(g.upstreams) == 0 {
+		return nil, errors.New("no upstream")
+	}
+
+	switch g.mode {
+	case Race:
+		return race(g.upstreams, req)
+	case Fallback:
+		return g.fallback(req)
+	default:
+		return sequential(g.upstreams, req)
+	}
+}
+
+func (g *Group) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			return &doConn{do: g.Do}, nil
+		},
+	}
+}
+
+//Stats the health of the upstreams
+func (g *Group) Stats() map[string]HealthStats {
+	s := make(map[string]HealthStats, len(g.upstreams))
+	for _, u := range g.upstreams {
+		s[u.Name] = u.Health.Stats()
+	}
+	return s
+}
+
+//fallback the first upstream and the others are queried concurrently,
+//the answer of the others is used if the answer of the first has an untrusted ip
+func (g *Group) fallback(req []byte) ([]byte, error) {
+	if len(g.upstreams) == 1 {
+		return do(g.upstreams[0], req)
+	}
+
+	type result struct {
+		resp []byte
+		err  error
+	}
+	c := make(chan result, 1)
+	go func() {
+		resp, err := race(g.upstreams[1:], req)
+		c <- result{resp, err}
+	}()
+
+	resp, err := do(g.upstreams[0], req)
+	if err == nil && g.trusted(req, resp) {
+		return resp, nil
+	}
+
+	r := <-c
+	if r.err != nil && err == nil {
+		return resp, nil
+	}
+	return r.resp, r.err
+}
+
+func (g *Group) trusted(req, resp []byte) bool {
+	if g.trust == nil {
+		return true
+	}
+	ips, _, _ := resolveTTL(req, resp)
+	for _, ip := range ips {
+		if !g.trust(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+//do query the upstream, the response with the rcode other than NOERROR and NXDOMAIN is a failure, eg: SERVFAIL, REFUSED
+func do(u Upstream, req []byte) ([]byte, error) {
+	start := time.Now()
+	resp, err := u.DNS.Do(req)
+	if err == nil {
+		if _, err = ParseResponse(req, resp); errors.Is(err, ErrNoSuchName) {
+			err = nil
+		}
+	}
+	u.Health.record(time.Since(start), err)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", u.Name, err)
+	}
+	return resp, nil
+}
+
+func sequential(us []Upstream, req []byte) ([]byte, error) {
+	var errs []string
+	for _, u := range us {
+		resp, err := do(u, req)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("all upstreams failed: %s", strings.Join(errs, "; "))
+}
+
+//race the slower upstreams keep running to record their health
+func race(us []Upstream, req []byte) ([]byte, error) {
+	type result struct {
+		resp []byte
+		err  error
+	}
+	c := make(chan result, len(us))
+	for _, u := range us {
+		go func(u Upstream) {
+			resp, err := do(u, req)
+			c <- result{resp, err}
+		}(u)
+	}
+
+	var errs []string
+	for range us {
+		r := <-c
+		if r.err == nil {
+			return r.resp, nil
+		}
+		errs = append(errs, r.err.Error())
+	}
+	return nil, fmt.Errorf("all upstreams failed: %s", strings.Join(errs, "; "))
+}
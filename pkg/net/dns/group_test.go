@@ -0,0 +1,124 @@
+package dns
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//mockUpstream answer the A queries with ip after delay, or fail if ip is nil, rcode: the rcode of the response
+type mockUpstream struct {
+	DNS
+	ip    net.IP
+	delay time.Duration
+	rcode dnsmessage.RCode
+}
+
+func (m *mockUpstream) Do(req []byte) ([]byte, error) {
+	time.Sleep(m.delay)
+	if m.ip == nil {
+		return nil, errors.New("mock failed")
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, err
+	}
+	msg.Response = true
+	msg.Additionals = nil
+	msg.RCode = m.rcode
+	q := msg.Questions[0]
+	if q.Type == dnsmessage.TypeA && m.rcode == dnsmessage.RCodeSuccess {
+		a := &dnsmessage.AResource{}
+		copy(a.A[:], m.ip.To4())
+		msg.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60},
+			Body:   a,
+		}}
+	}
+	return msg.Pack()
+}
+
+func TestGroup(t *testing.T) {
+	failed := Upstream{Name: "failed", DNS: &mockUpstream{}}
+	slow := Upstream{Name: "slow", DNS: &mockUpstream{ip: net.IP{1, 1, 1, 1}, delay: 100 * time.Millisecond}}
+	fast := Upstream{Name: "fast", DNS: &mockUpstream{ip: net.IP{2, 2, 2, 2}}}
+	local := Upstream{Name: "local", DNS: &mockUpstream{ip: net.IP{10, 0, 0, 1}}}
+
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+	trust := WithTrust(private.Contains)
+	for _, v := range []struct {
+		name string
+		g    *Group
+		want net.IP
+	}{
+		{"sequential", NewGroup(Sequential, nil, []Upstream{failed, slow, fast}), net.IP{1, 1, 1, 1}},
+		{"race", NewGroup(Race, nil, []Upstream{failed, slow, fast}), net.IP{2, 2, 2, 2}},
+		{"fallback trusted", NewGroup(Fallback, nil, []Upstream{local, slow, fast}, trust), net.IP{10, 0, 0, 1}},
+		{"fallback untrusted", NewGroup(Fallback, nil, []Upstream{fast, slow, failed}, trust), net.IP{1, 1, 1, 1}},
+		{"fallback failed", NewGroup(Fallback, nil, []Upstream{local, failed}, WithTrust(func(net.IP) bool { return false })), net.IP{10, 0, 0, 1}},
+	} {
+		ips, err := v.g.LookupIP("www.example.com")
+		if err != nil {
+			t.Errorf("%s: %v", v.name, err)
+			continue
+		}
+		if len(ips) != 1 || !ips[0].Equal(v.want) {
+			t.Errorf("%s: want %v, got %v", v.name, v.want, ips)
+		}
+	}
+
+	g := NewGroup(Race, nil, []Upstream{failed})
+	if _, err := g.LookupIP("www.example.com"); err == nil {
+		t.Error("want error when all upstreams failed")
+	}
+	s := g.Stats()["failed"]
+	t.Log(s)
+	if s.Success != 0 || s.Failure == 0 || s.Error == "" {
+		t.Errorf("want failure recorded, got %+v", s)
+	}
+}
+
+func TestGroupRCode(t *testing.T) {
+	servfail := Upstream{Name: "servfail", DNS: &mockUpstream{ip: net.IP{3, 3, 3, 3}, rcode: dnsmessage.RCodeServerFailure}}
+	refused := Upstream{Name: "refused", DNS: &mockUpstream{ip: net.IP{4, 4, 4, 4}, rcode: dnsmessage.RCodeRefused}}
+	nxdomain := Upstream{Name: "nxdomain", DNS: &mockUpstream{ip: net.IP{5, 5, 5, 5}, rcode: dnsmessage.RCodeNameError}}
+	slow := Upstream{Name: "slow", DNS: &mockUpstream{ip: net.IP{1, 1, 1, 1}, delay: 50 * time.Millisecond}}
+
+	for _, mode := range []Mode{Sequential, Race} {
+		g := NewGroup(mode, nil, []Upstream{servfail, refused, slow})
+		ips, err := g.LookupIP("www.example.com")
+		if err != nil || len(ips) != 1 || !ips[0].Equal(net.IP{1, 1, 1, 1}) {
+			t.Errorf("%s: want the answer of slow, got %v %v", mode, ips, err)
+		}
+		for _, name := range []string{"servfail", "refused"} {
+			if s := g.Stats()[name]; s.Failure == 0 || s.Error == "" {
+				t.Errorf("%s: want the failure of %s recorded, got %+v", mode, name, s)
+			}
+		}
+	}
+
+	// NXDOMAIN is a good answer
+	g := NewGroup(Sequential, nil, []Upstream{nxdomain, slow})
+	if _, err := g.LookupIP("www.example.com"); !errors.Is(err, ErrNoSuchName) {
+		t.Errorf("want NXDOMAIN, got %v", err)
+	}
+	if s := g.Stats()["slow"]; s.Success != 0 || s.Failure != 0 {
+		t.Errorf("want slow not queried, got %+v", s)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	h := &Health{}
+	h.record(10*time.Millisecond, nil)
+	h.record(0, errors.New("timeout"))
+	h.record(20*time.Millisecond, nil)
+
+	s := h.Stats()
+	if s.Success != 2 || s.Failure != 1 || s.Latency != 20*time.Millisecond || s.Error != "" {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
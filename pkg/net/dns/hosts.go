@@ -0,0 +1,255 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//hostsTTL the ttl of the answers from the hosts
+const hostsTTL = 60
+
+//Hosts the static mapping of the domains, it is safe to Set while looking up.
+//key: the exact domain, or the wildcard *.example.com matching the subdomains of example.com,
+//value: the ips separated by comma, or an alias domain, the port is used only when dialing,
+//eg: 10.0.0.1, 10.0.0.1,fd00::1, staging.example.com, 10.0.0.1:8080, [fd00::1]:8080
+type Hosts struct {
+	exact    map[string]*hostsEntry
+	wildcard map[string]*hostsEntry
+	lock     sync.RWMutex
+}
+
+type hostsEntry struct {
+	ips   []net.IP
+	alias string
+	port  string
+}
+
+//NewHosts create the hosts, the invalid values are ignored
+func NewHosts(m map[string]string) *Hosts {
+	h := &Hosts{}
+	h.Set(m)
+	return h
+}
+
+//Set replace the mapping, the invalid values are ignored
+func (h *Hosts) Set(m map[string]string) {
+	exact, wildcard := make(map[string]*hostsEntry), make(map[string]*hostsEntry)
+	for k, v := range m {
+		e, err := parseHostsEntry(v)
+		if err != nil {
+			continue
+		}
+		k = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(k), "."))
+		if strings.HasPrefix(k, "*.") {
+			wildcard[k[2:]] = e
+		} else if k != "" {
+			exact[k] = e
+		}
+	}
+
+	h.lock.Lock()
+	h.exact, h.wildcard = exact, wildcard
+	h.lock.Unlock()
+}
+
+func parseHostsEntry(v string) (*hostsEntry, error) {
+	v = strings.TrimSpace(v)
+	e := &hostsEntry{}
+	if host, port, err := net.SplitHostPort(v); err == nil {
+		v, e.port = host, port
+	}
+
+	for _, s := range strings.Split(v, ",") {
+		if ip := net.ParseIP(strings.TrimSpace(s)); ip != nil {
+			e.ips = append(e.ips, ip)
+		}
+	}
+	if len(e.ips) != 0 {
+		return e, nil
+	}
+
+	if v == "" || strings.ContainsAny(v, ", ") {
+		return nil, fmt.Errorf("invalid hosts value: %s", v)
+	}
+	e.alias = strings.ToLower(strings.TrimSuffix(v, "."))
+	return e, nil
+}
+
+//get the exact mapping, or the longest wildcard mapping
+func (h *Hosts) get(domain string) *hostsEntry {
+	if h == nil {
+		return nil
+	}
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if e, ok := h.exact[domain]; ok {
+		return e
+	}
+	for i := strings.IndexByte(domain, '.'); i != -1; {
+		if e, ok := h.wildcard[domain[i+1:]]; ok {
+			return e
+		}
+		j := strings.IndexByte(domain[i+1:], '.')
+		if j == -1 {
+			break
+		}
+		i += j + 1
+	}
+	return nil
+}
+
+//resolve follow the aliases, return the last entry and the last alias
+func (h *Hosts) resolve(domain string) (*hostsEntry, string, string, bool) {
+	var port string
+	e := h.get(domain)
+	if e == nil {
+		return nil, "", "", false
+	}
+	for i := 0; ; i++ {
+		if port == "" {
+			port = e.port
+		}
+		if e.alias == "" || i >= maxCNAME {
+			return e, e.alias, port, true
+		}
+		next := h.get(e.alias)
+		if next == nil {
+			return e, e.alias, port, true
+		}
+		e = next
+	}
+}
+
+//Lookup the ips of the domain, or the alias domain to resolve if the domain is mapped to a domain
+func (h *Hosts) Lookup(domain string) ([]net.IP, string, bool) {
+	e, alias, _, ok := h.resolve(domain)
+	if !ok {
+		return nil, "", false
+	}
+	if len(e.ips) != 0 {
+		return e.ips, "", true
+	}
+	return nil, alias, true
+}
+
+//Dial the address to dial for host:port, the first ip or the alias, and the port of the mapping if it is set
+func (h *Hosts) Dial(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	e, alias, p, ok := h.resolve(host)
+	if !ok {
+		return addr
+	}
+	if p != "" {
+		port = p
+	}
+	if len(e.ips) != 0 {
+		return net.JoinHostPort(e.ips[0].String(), port)
+	}
+	return net.JoinHostPort(alias, port)
+}
+
+//Len the count of the mappings
+func (h *Hosts) Len() int {
+	if h == nil {
+		return 0
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return len(h.exact) + len(h.wildcard)
+}
+
+//WithHosts the domains in the hosts are answered before querying the server
+func WithHosts(h *Hosts) Option {
+	return func(o *option) { o.hosts = h }
+}
+
+//lookup answer by the hosts, or query the alias or domain by the cache and f
+func (o *option) lookup(domain string, f func(string) ([]net.IP, uint32, error)) ([]net.IP, error) {
+	ips, alias, ok := o.hosts.Lookup(domain)
+	if !ok {
+		return o.cache.Lookup(domain, f)
+	}
+	if alias != "" {
+		return o.cache.Lookup(alias, f)
+	}
+	if ips = o.strategy.Apply(ips); len(ips) == 0 {
+		return nil, fmt.Errorf("no address of %s in the hosts for %s", domain, o.strategy)
+	}
+	return ips, nil
+}
+
+//hostsDo answer the request by the hosts, the domain mapped to an alias is answered by the cname and the answer of the alias,
+//the other requests are sent by f
+func (o *option) hostsDo(req []byte, f func([]byte) ([]byte, error)) ([]byte, error) {
+	if o.hosts.Len() == 0 {
+		return f(req)
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil || len(msg.Questions) == 0 {
+		return f(req)
+	}
+	q := msg.Questions[0]
+	ips, alias, ok := o.hosts.Lookup(q.Name.String())
+	if !ok || q.Class != dnsmessage.ClassINET {
+		return f(req)
+	}
+
+	if alias != "" {
+		return aliasDo(msg, alias, f)
+	}
+
+	msg.Response, msg.RecursionAvailable, msg.Authoritative = true, true, true
+	msg.Answers, msg.Authorities, msg.Additionals = nil, nil, nil
+	h := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: hostsTTL}
+	for _, ip := range ips {
+		switch {
+		case q.Type == A && ip.To4() != nil:
+			a := &dnsmessage.AResource{}
+			copy(a.A[:], ip.To4())
+			msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: h, Body: a})
+		case q.Type == AAAA && ip.To4() == nil:
+			a := &dnsmessage.AAAAResource{}
+			copy(a.AAAA[:], ip.To16())
+			msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: h, Body: a})
+		}
+	}
+	return msg.Pack()
+}
+
+//aliasDo query the alias, the answer is prefixed by the cname of the question
+func aliasDo(msg dnsmessage.Message, alias string, f func([]byte) ([]byte, error)) ([]byte, error) {
+	q := msg.Questions[0]
+	name, err := dnsmessage.NewName(alias + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias %s: %v", alias, err)
+	}
+	msg.Questions = []dnsmessage.Question{{Name: name, Type: q.Type, Class: q.Class}}
+	req, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = msg.Unpack(resp); err != nil {
+		return nil, fmt.Errorf("parse response failed: %v", err)
+	}
+	msg.Questions = []dnsmessage.Question{q}
+	msg.Answers = append([]dnsmessage.Resource{{
+		Header: dnsmessage.ResourceHeader{Name: q.Name, Type: CNAME, Class: q.Class, TTL: hostsTTL},
+		Body:   &dnsmessage.CNAMEResource{CNAME: name},
+	}}, msg.Answers...)
+	return msg.Pack()
+}
@@ -0,0 +1,108 @@
+package dns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestHosts(t *testing.T) {
+	h := NewHosts(map[string]string{
+		"staging.internal":     "10.0.0.1",
+		"*.staging.internal":   "10.0.0.2,fd00::2",
+		"*.a.staging.internal": "[fd00::3]:8443",
+		"api.internal":         "staging.internal:8080",
+		"cdn.internal":         "www.example.com.",
+		"invalid.internal":     "a b",
+	})
+
+	for _, c := range []struct {
+		domain string
+		ips    int
+		alias  string
+		ok     bool
+	}{
+		{"staging.internal", 1, "", true},
+		{"Staging.Internal.", 1, "", true},
+		{"x.y.staging.internal", 2, "", true},
+		{"x.a.staging.internal", 1, "", true},
+		{"api.internal", 1, "", true},
+		{"cdn.internal", 0, "www.example.com", true},
+		{"invalid.internal", 0, "", false},
+		{"internal", 0, "", false},
+	} {
+		ips, alias, ok := h.Lookup(c.domain)
+		if len(ips) != c.ips || alias != c.alias || ok != c.ok {
+			t.Errorf("%s: want %d %s %v, got %v %s %v", c.domain, c.ips, c.alias, c.ok, ips, alias, ok)
+		}
+	}
+
+	for addr, want := range map[string]string{
+		"staging.internal:443":     "10.0.0.1:443",
+		"api.internal:443":         "10.0.0.1:8080",
+		"x.a.staging.internal:443": "[fd00::3]:8443",
+		"cdn.internal:80":          "www.example.com:80",
+		"example.com:443":          "example.com:443",
+	} {
+		if x := h.Dial(addr); x != want {
+			t.Errorf("%s: want %s, got %s", addr, want, x)
+		}
+	}
+
+	h.Set(nil)
+	if _, _, ok := h.Lookup("staging.internal"); ok || h.Len() != 0 {
+		t.Error("want the hosts cleared")
+	}
+}
+
+func TestHostsDNS(t *testing.T) {
+	h := NewHosts(map[string]string{
+		"staging.internal": "10.0.0.1,fd00::1",
+		"cdn.internal":     "www.example.com",
+	})
+	g := NewGroup(Sequential, nil, []Upstream{{Name: "mock", DNS: &mockUpstream{ip: net.IP{1, 2, 3, 4}}}},
+		WithHosts(h), WithStrategy(utils.IPv4Only))
+
+	ips, err := g.LookupIP("staging.internal")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("want 10.0.0.1, got %v %v", ips, err)
+	}
+	ips, err = g.LookupIP("cdn.internal")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(1, 2, 3, 4)) {
+		t.Errorf("want the ips of the alias, got %v %v", ips, err)
+	}
+
+	req, _ := NewRequest("staging.internal", AAAA)
+	resp, err := g.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ParseResponse(req, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ips, _ := r.IPs(); len(ips) != 1 || !ips[0].Equal(net.ParseIP("fd00::1")) {
+		t.Errorf("want fd00::1, got %v", ips)
+	}
+
+	req, _ = NewRequest("cdn.internal", A)
+	resp, err = g.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = ParseResponse(req, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := r.Chain()
+	ips, _ = r.IPs()
+	t.Log(chain, ips)
+	if len(chain) != 2 || chain[1].String() != "www.example.com." || len(ips) != 1 {
+		t.Errorf("want the cname and the answer of the alias, got %v %v", chain, ips)
+	}
+	if r.Answers[0].Header.Type != dnsmessage.TypeCNAME {
+		t.Errorf("want the cname first, got %v", r.Answers)
+	}
+}
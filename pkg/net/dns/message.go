@@ -0,0 +1,389 @@
+package dns
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//Type the type of the resource records
+type Type = dnsmessage.Type
+
+const (
+	A     = dnsmessage.TypeA
+	NS    = dnsmessage.TypeNS
+	CNAME = dnsmessage.TypeCNAME
+	SOA   = dnsmessage.TypeSOA
+	PTR   = dnsmessage.TypePTR
+	MX    = dnsmessage.TypeMX
+	TXT   = dnsmessage.TypeTXT
+	AAAA  = dnsmessage.TypeAAAA // https://www.ietf.org/rfc/rfc3596.txt
+	SRV   = dnsmessage.TypeSRV
+	OPT   = dnsmessage.TypeOPT
+
+	// dnssec https://tools.ietf.org/html/rfc4034
+	DS     Type = 43
+	RRSIG  Type = 46
+	NSEC   Type = 47
+	DNSKEY Type = 48
+	NSEC3  Type = 50
+
+	// https://datatracker.ietf.org/doc/html/rfc9460
+	SVCB  Type = 64
+	HTTPS Type = 65
+
+	// only for req
+	AXFR = dnsmessage.TypeAXFR
+	ANY  = dnsmessage.TypeALL
+)
+
+//maxCNAME the max length of the cname chain
+const maxCNAME = 8
+
+//RCodeError the response code is not success
+type RCodeError dnsmessage.RCode
+
+func (r RCodeError) Error() string {
+	switch dnsmessage.RCode(r) {
+	case dnsmessage.RCodeFormatError:
+		return "request format error"
+	case dnsmessage.RCodeServerFailure:
+		return "dns server failure"
+	case dnsmessage.RCodeNotImplemented:
+		return "dns server not support this request"
+	case dnsmessage.RCodeRefused:
+		return "dns server refused"
+	default:
+		return fmt.Sprintf("dns response code: %d", r)
+	}
+}
+
+//ErrNoSuchName the response code is NXDOMAIN
+var ErrNoSuchName = errors.New("no such name")
+
+//NewRequest create the query message with a random id,
+//the OPT record with the 4096 bytes payload size and the options is added
+func NewRequest(domain string, t Type, options ...dnsmessage.Option) ([]byte, error) {
+	return newRequest(domain, t, false, options)
+}
+
+//NewDNSSECRequest create the query message like NewRequest, the DO and CD bits are set,
+//so the server returns the dnssec records and doesn't filter the bogus answers
+func NewDNSSECRequest(domain string, t Type, options ...dnsmessage.Option) ([]byte, error) {
+	b, err := newRequest(domain, t, true, options)
+	if err != nil {
+		return nil, err
+	}
+	b[3] |= cdBit
+	return b, nil
+}
+
+//the header bits are not supported by dnsmessage, they are in the 4th byte of the message
+const (
+	adBit = 0x20
+	cdBit = 0x10
+)
+
+func newRequest(domain string, t Type, dnssec bool, options []dnsmessage.Option) ([]byte, error) {
+	if !strings.HasSuffix(domain, ".") {
+		domain += "."
+	}
+	name, err := dnsmessage.NewName(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %s: %v", domain, err)
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err = opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, dnssec); err != nil {
+		return nil, err
+	}
+
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: uint16(rand.Intn(math.MaxUint16 + 1)), RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: name, Type: t, Class: dnsmessage.ClassINET}},
+		Additionals: []dnsmessage.Resource{{
+			Header: opt,
+			Body:   &dnsmessage.OPTResource{Options: options},
+		}},
+	}
+	return msg.Pack()
+}
+
+//Response the parsed response message
+type Response struct {
+	dnsmessage.Message
+	// EDNS the OPT record, nil if the response has no OPT record
+	EDNS *EDNS
+}
+
+//ParseResponse parse the response of req, the id and question must be same as the request,
+//the response code error is returned with the parsed response, ErrNoSuchName for NXDOMAIN
+func ParseResponse(req, resp []byte) (*Response, error) {
+	var q dnsmessage.Message
+	if err := q.Unpack(req); err != nil {
+		return nil, fmt.Errorf("parse request failed: %v", err)
+	}
+
+	r := &Response{}
+	if err := r.Unpack(resp); err != nil {
+		return nil, fmt.Errorf("parse response failed: %v", err)
+	}
+
+	if r.ID != q.ID {
+		return nil, errors.New("id not same")
+	}
+	if !r.Header.Response {
+		return nil, errors.New("the qr is not 1(Answer)")
+	}
+	if len(q.Questions) != 0 && len(r.Questions) != 0 &&
+		(!equalName(q.Questions[0].Name, r.Questions[0].Name) || q.Questions[0].Type != r.Questions[0].Type) {
+		return nil, fmt.Errorf("question not same: %v", r.Questions[0])
+	}
+
+	for _, a := range r.Additionals {
+		if a.Header.Type == OPT {
+			r.EDNS = ParseEDNS(a)
+			break
+		}
+	}
+
+	switch r.RCode {
+	case dnsmessage.RCodeSuccess:
+		return r, nil
+	case dnsmessage.RCodeNameError:
+		return r, ErrNoSuchName
+	default:
+		return r, RCodeError(r.RCode)
+	}
+}
+
+//Question the question of the response, zero question if the response has no question
+func (r *Response) Question() dnsmessage.Question {
+	if len(r.Questions) == 0 {
+		return dnsmessage.Question{}
+	}
+	return r.Questions[0]
+}
+
+//Chain the cname chain from the question name, the last is the canonical name
+func (r *Response) Chain() []dnsmessage.Name {
+	chain := []dnsmessage.Name{r.Question().Name}
+	for len(chain) <= maxCNAME {
+		next, ok := r.cname(chain[len(chain)-1])
+		if !ok {
+			break
+		}
+		chain = append(chain, next)
+	}
+	return chain
+}
+
+func (r *Response) cname(name dnsmessage.Name) (dnsmessage.Name, bool) {
+	for _, a := range r.Answers {
+		if c, ok := a.Body.(*dnsmessage.CNAMEResource); ok && equalName(a.Header.Name, name) {
+			return c.CNAME, true
+		}
+	}
+	return dnsmessage.Name{}, false
+}
+
+//Records the answers of type t that belong to the cname chain, and the min ttl of the records and cnames,
+//the ttl is math.MaxUint32 if no record and cname
+func (r *Response) Records(t Type) ([]dnsmessage.Resource, uint32) {
+	chain := r.Chain()
+	ttl := uint32(math.MaxUint32)
+	var rs []dnsmessage.Resource
+	for _, a := range r.Answers {
+		if !inChain(chain, a.Header.Name) {
+			continue
+		}
+		switch a.Header.Type {
+		case t:
+			rs = append(rs, a)
+		case CNAME:
+		default:
+			continue
+		}
+		if a.Header.TTL < ttl {
+			ttl = a.Header.TTL
+		}
+	}
+	return rs, ttl
+}
+
+//IPs the A and AAAA records of the cname chain, and the min ttl of them
+func (r *Response) IPs() ([]net.IP, uint32) {
+	as, ttl := r.Records(A)
+	aaaas, ttl2 := r.Records(AAAA)
+	if ttl2 < ttl {
+		ttl = ttl2
+	}
+
+	ips := make([]net.IP, 0, len(as)+len(aaaas))
+	for _, a := range append(as, aaaas...) {
+		switch b := a.Body.(type) {
+		case *dnsmessage.AResource:
+			ips = append(ips, append(net.IP(nil), b.A[:]...))
+		case *dnsmessage.AAAAResource:
+			ips = append(ips, append(net.IP(nil), b.AAAA[:]...))
+		}
+	}
+	return ips, ttl
+}
+
+//HTTPS the HTTPS records of the cname chain
+func (r *Response) HTTPS() ([]*SVCBRecord, error) {
+	rs, _ := r.Records(HTTPS)
+	s := make([]*SVCBRecord, 0, len(rs))
+	for _, x := range rs {
+		u, ok := x.Body.(*dnsmessage.UnknownResource)
+		if !ok {
+			continue
+		}
+		v, err := ParseSVCB(u.Data)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, v)
+	}
+	return s, nil
+}
+
+func inChain(chain []dnsmessage.Name, name dnsmessage.Name) bool {
+	for i := range chain {
+		if equalName(chain[i], name) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalName(a, b dnsmessage.Name) bool {
+	return strings.EqualFold(a.String(), b.String())
+}
+
+//Resolve get the ips of the answer of req
+func Resolve(req, answer []byte) ([]net.IP, error) {
+	ips, _, err := resolveTTL(req, answer)
+	return ips, err
+}
+
+//resolveTTL resolve the answer, return the ips and the min ttl of the records
+func resolveTTL(req, answer []byte) ([]net.IP, uint32, error) {
+	r, err := ParseResponse(req, answer)
+	if err != nil {
+		return nil, 0, err
+	}
+	ips, ttl := r.IPs()
+	return ips, ttl, nil
+}
+
+//SVCBRecord the SVCB or HTTPS record, https://datatracker.ietf.org/doc/html/rfc9460
+type SVCBRecord struct {
+	// Priority 0 is the alias mode
+	Priority uint16
+	Target   string
+	ALPN     []string
+	Port     uint16
+	IPv4Hint []net.IP
+	IPv6Hint []net.IP
+	ECH      []byte
+	// Params all params, key is the SvcParamKey
+	Params map[uint16][]byte
+}
+
+//the SvcParamKeys
+const (
+	svcbALPN     = 1
+	svcbPort     = 3
+	svcbIPv4Hint = 4
+	svcbECH      = 5
+	svcbIPv6Hint = 6
+)
+
+var errSVCB = errors.New("invalid svcb record")
+
+var errName = errors.New("invalid name")
+
+//ParseSVCB parse the rdata of the SVCB or HTTPS record
+func ParseSVCB(data []byte) (*SVCBRecord, error) {
+	if len(data) < 3 {
+		return nil, errSVCB
+	}
+	s := &SVCBRecord{Priority: binary.BigEndian.Uint16(data), Params: map[uint16][]byte{}}
+
+	target, n, err := readName(data, 2)
+	if err != nil {
+		return nil, err
+	}
+	s.Target = target
+
+	for i := 2 + n; i < len(data); {
+		if i+4 > len(data) {
+			return nil, errSVCB
+		}
+		key, l := binary.BigEndian.Uint16(data[i:]), int(binary.BigEndian.Uint16(data[i+2:]))
+		i += 4
+		if i+l > len(data) {
+			return nil, errSVCB
+		}
+		v := data[i : i+l]
+		i += l
+
+		s.Params[key] = v
+		switch key {
+		case svcbALPN:
+			for j := 0; j < len(v); {
+				k := int(v[j]) + 1
+				if j+k > len(v) {
+					return nil, errSVCB
+				}
+				s.ALPN = append(s.ALPN, string(v[j+1:j+k]))
+				j += k
+			}
+		case svcbPort:
+			if len(v) != 2 {
+				return nil, errSVCB
+			}
+			s.Port = binary.BigEndian.Uint16(v)
+		case svcbIPv4Hint:
+			for j := 0; j+4 <= len(v); j += 4 {
+				s.IPv4Hint = append(s.IPv4Hint, net.IP(append([]byte(nil), v[j:j+4]...)))
+			}
+		case svcbECH:
+			s.ECH = v
+		case svcbIPv6Hint:
+			for j := 0; j+16 <= len(v); j += 16 {
+				s.IPv6Hint = append(s.IPv6Hint, net.IP(append([]byte(nil), v[j:j+16]...)))
+			}
+		}
+	}
+	return s, nil
+}
+
+//readName read the uncompressed name at off, return the name and the length
+func readName(data []byte, off int) (string, int, error) {
+	var s strings.Builder
+	for i := off; i < len(data); {
+		l := int(data[i])
+		if l == 0 {
+			if s.Len() == 0 {
+				return ".", i + 1 - off, nil
+			}
+			return s.String(), i + 1 - off, nil
+		}
+		if l&0xC0 != 0 || i+1+l > len(data) {
+			return "", 0, errName
+		}
+		s.Write(data[i+1 : i+1+l])
+		s.WriteByte('.')
+		i += 1 + l
+	}
+	return "", 0, errName
+}
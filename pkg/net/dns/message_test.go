@@ -0,0 +1,168 @@
+package dns
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestMessage(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("1.2.3.0/24")
+	req, err := NewRequest("www.example.com", A, clientSubnetOption(subnet))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var q dnsmessage.Message
+	if err = q.Unpack(req); err != nil {
+		t.Fatal(err)
+	}
+	name := q.Questions[0].Name
+	cname := dnsmessage.MustNewName("www.example.com.cdn.net.")
+	h := func(n dnsmessage.Name, t Type, ttl uint32) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: n, Type: t, Class: dnsmessage.ClassINET, TTL: ttl}
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: q.ID, Response: true, RecursionAvailable: true})
+	b.EnableCompression()
+	_ = b.StartQuestions()
+	_ = b.Question(q.Questions[0])
+	_ = b.StartAnswers()
+	_ = b.CNAMEResource(h(name, CNAME, 300), dnsmessage.CNAMEResource{CNAME: cname})
+	_ = b.AResource(h(cname, A, 60), dnsmessage.AResource{A: [4]byte{1, 1, 1, 1}})
+	_ = b.AResource(h(dnsmessage.MustNewName("other.example.com."), A, 10), dnsmessage.AResource{A: [4]byte{2, 2, 2, 2}})
+	_ = b.TXTResource(h(cname, TXT, 60), dnsmessage.TXTResource{TXT: []string{"txt"}})
+	_ = b.MXResource(h(cname, MX, 60), dnsmessage.MXResource{Pref: 10, MX: cname})
+	_ = b.SRVResource(h(cname, SRV, 60), dnsmessage.SRVResource{Priority: 1, Weight: 2, Port: 443, Target: cname})
+	_ = b.StartAdditionals()
+	var opt dnsmessage.ResourceHeader
+	_ = opt.SetEDNS0(1232, dnsmessage.RCodeSuccess, true)
+	_ = b.OPTResource(opt, dnsmessage.OPTResource{Options: []dnsmessage.Option{clientSubnetOption(subnet)}})
+	resp, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ParseResponse(req, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ips, ttl := r.IPs()
+	t.Log(r.Chain(), ips, ttl)
+	if len(ips) != 1 || !ips[0].Equal(net.IPv4(1, 1, 1, 1)) || ttl != 60 {
+		t.Errorf("want [1.1.1.1] 60, got %v %d", ips, ttl)
+	}
+	for _, x := range []Type{TXT, MX, SRV} {
+		if rs, _ := r.Records(x); len(rs) != 1 {
+			t.Errorf("want one %v record, got %v", x, rs)
+		}
+	}
+	if rs, _ := r.Records(SRV); rs[0].Body.(*dnsmessage.SRVResource).Target.String() != cname.String() {
+		t.Errorf("unexpected srv record: %v", rs[0])
+	}
+
+	if r.EDNS == nil || r.EDNS.UDPSize != 1232 || !r.EDNS.DNSSECOK {
+		t.Fatalf("unexpected edns: %+v", r.EDNS)
+	}
+	c, err := r.EDNS.ClientSubnet()
+	if err != nil || c.SourcePrefix != 24 || !c.IP.Equal(net.IPv4(1, 2, 3, 0)) {
+		t.Errorf("unexpected client subnet: %+v %v", c, err)
+	}
+}
+
+func TestNXDomain(t *testing.T) {
+	req, _ := NewRequest("nx.example.com", AAAA)
+	var q dnsmessage.Message
+	_ = q.Unpack(req)
+	q.Response, q.RCode, q.Additionals = true, dnsmessage.RCodeNameError, nil
+	resp, _ := q.Pack()
+
+	if _, err := ParseResponse(req, resp); err != ErrNoSuchName {
+		t.Errorf("want ErrNoSuchName, got %v", err)
+	}
+
+	q.RCode = dnsmessage.RCodeRefused
+	resp, _ = q.Pack()
+	if _, err := ParseResponse(req, resp); err == nil || err.Error() != "dns server refused" {
+		t.Errorf("want refused, got %v", err)
+	}
+}
+
+func TestSVCB(t *testing.T) {
+	// 1 . alpn=h2,h3 port=8443 ipv4hint=1.2.3.4
+	data := []byte{0, 1, 0,
+		0, 1, 0, 6, 2, 'h', '2', 2, 'h', '3',
+		0, 3, 0, 2, 0x20, 0xfb,
+		0, 4, 0, 4, 1, 2, 3, 4,
+	}
+	s, err := ParseSVCB(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(s)
+	if s.Priority != 1 || s.Target != "." || len(s.ALPN) != 2 || s.ALPN[1] != "h3" || s.Port != 8443 ||
+		len(s.IPv4Hint) != 1 || !s.IPv4Hint[0].Equal(net.IPv4(1, 2, 3, 4)) {
+		t.Errorf("unexpected svcb: %+v", s)
+	}
+
+	if _, err = ParseSVCB(data[:len(data)-1]); err == nil {
+		t.Error("want error for the truncated record")
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	u, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+	l, err := net.Listen("tcp", u.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	go func() {
+		b := make([]byte, 512)
+		n, addr, err := u.ReadFrom(b)
+		if err != nil {
+			return
+		}
+		var msg dnsmessage.Message
+		_ = msg.Unpack(b[:n])
+		msg.Response, msg.Truncated, msg.Additionals = true, true, nil
+		resp, _ := msg.Pack()
+		_, _ = u.WriteTo(resp, addr)
+	}()
+
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		length := make([]byte, 2)
+		if _, err = io.ReadFull(c, length); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint16(length))
+		if _, err = io.ReadFull(c, req); err != nil {
+			return
+		}
+		resp, _ := answer(req)
+		_, _ = c.Write(append([]byte{byte(len(resp) >> 8), byte(len(resp))}, resp...))
+	}()
+
+	ips, err := NewDNS(u.LocalAddr().String(), nil, nil, WithStrategy(utils.IPv4Only)).LookupIP("www.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 1 || !ips[0].Equal(net.IPv4(1, 2, 3, 4)) {
+		t.Errorf("want the answer from tcp, got %v", ips)
+	}
+}
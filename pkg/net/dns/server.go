@@ -0,0 +1,95 @@
+package dns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+)
+
+//Handler handle the dns request message, return the response message
+type Handler func(req []byte) ([]byte, error)
+
+var _ proxy.Server = (*server)(nil)
+
+//server the dns server listen on both udp and tcp of the same address
+type server struct {
+	udp proxy.Server
+	tcp proxy.Server
+}
+
+//NewServer create a dns server, host can be empty and set later by SetServer
+func NewServer(host string, h Handler) (proxy.Server, error) {
+	s := &server{}
+
+	var err error
+	s.udp, err = proxy.NewUDPServer("", proxy.UDPWithHandle(func(b []byte, _ proxy.Proxy) ([]byte, error) {
+		return h(b)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("create dns udp server failed: %v", err)
+	}
+
+	s.tcp, err = proxy.NewTCPServer("", proxy.TCPWithHandle(func(c net.Conn, _ proxy.Proxy) {
+		if err := handleTCP(c, h); err != nil && err != io.EOF {
+			log.Printf("handle dns tcp conn failed: %v", err)
+		}
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("create dns tcp server failed: %v", err)
+	}
+
+	return s, s.SetServer(host)
+}
+
+//handleTCP the messages over tcp are prefixed with the two bytes length
+func handleTCP(c net.Conn, h Handler) error {
+	defer c.Close()
+
+	length := make([]byte, 2)
+	for {
+		_ = c.SetReadDeadline(time.Now().Add(10 * time.Second))
+		if _, err := io.ReadFull(c, length); err != nil {
+			return err
+		}
+
+		req := make([]byte, binary.BigEndian.Uint16(length))
+		if _, err := io.ReadFull(c, req); err != nil {
+			return fmt.Errorf("read dns request failed: %v", err)
+		}
+
+		resp, err := h(req)
+		if err != nil {
+			return err
+		}
+
+		if _, err = c.Write(append([]byte{byte(len(resp) >> 8), byte(len(resp))}, resp...)); err != nil {
+			return fmt.Errorf("write dns response failed: %v", err)
+		}
+	}
+}
+
+func (s *server) SetProxy(proxy.Proxy) {}
+
+func (s *server) SetServer(host string) error {
+	if err := s.udp.SetServer(host); err != nil {
+		return err
+	}
+	if err := s.tcp.SetServer(host); err != nil {
+		_ = s.udp.SetServer("")
+		return err
+	}
+	return nil
+}
+
+func (s *server) Close() error {
+	err := s.udp.Close()
+	if er := s.tcp.Close(); er != nil {
+		err = er
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package dns
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	s, err := NewServer("127.0.0.1:15353", func(req []byte) ([]byte, error) {
+		return append([]byte("resp:"), req...), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	u, err := net.Dial("udp", "127.0.0.1:15353")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+	_, _ = u.Write([]byte("udp"))
+	b := make([]byte, 100)
+	n, err := u.Read(b)
+	if err != nil || string(b[:n]) != "resp:udp" {
+		t.Error("unexpected udp response", string(b[:n]), err)
+	}
+
+	c, err := net.Dial("tcp", "127.0.0.1:15353")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	for i := 0; i < 2; i++ {
+		_, _ = c.Write([]byte{0, 3, 't', 'c', 'p'})
+		n, err = c.Read(b)
+		if err != nil || !bytes.Equal(b[:n], []byte("\x00\x08resp:tcp")) {
+			t.Error("unexpected tcp response", b[:n], err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package dns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//answer answer the A and AAAA queries with 1.2.3.4 and 2001:db8::1
+func answer(req []byte) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, err
+	}
+	msg.Response = true
+	msg.Additionals = nil
+
+	q := msg.Questions[0]
+	h := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60}
+	switch q.Type {
+	case dnsmessage.TypeA:
+		msg.Answers = []dnsmessage.Resource{{Header: h, Body: &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}}}}
+	case dnsmessage.TypeAAAA:
+		a := &dnsmessage.AAAAResource{}
+		copy(a.AAAA[:], net.ParseIP("2001:db8::1"))
+		msg.Answers = []dnsmessage.Resource{{Header: h, Body: a}}
+	}
+	return msg.Pack()
+}
+
+func TestLookupIPStrategy(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("0.0.0.0/0")
+	v4, v6 := net.ParseIP("1.2.3.4"), net.ParseIP("2001:db8::1")
+
+	for s, want := range map[utils.Strategy][]net.IP{
+		utils.IPv4Only:   {v4},
+		utils.IPv6Only:   {v6},
+		utils.PreferIPv4: {v4, v6},
+		utils.PreferIPv6: {v6, v4},
+	} {
+		ips, ttl, err := lookupIP("www.example.com", subnet, s, answer)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		t.Log(s, ips, ttl)
+		if ttl != 60 {
+			t.Errorf("%v: want ttl 60, got %d", s, ttl)
+		}
+		if len(ips) != len(want) {
+			t.Errorf("%v: want %v, got %v", s, want, ips)
+			continue
+		}
+		for i := range ips {
+			if !ips[i].Equal(want[i]) {
+				t.Errorf("%v: want %v, got %v", s, want, ips)
+			}
+		}
+	}
+}
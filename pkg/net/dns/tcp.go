@@ -0,0 +1,97 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+)
+
+var _ DNS = (*tcp)(nil)
+
+//tcp dns over tcp, the message is prefixed with the two bytes length
+type tcp struct {
+	host   string
+	subnet *net.IPNet
+	proxy  proxy.Proxy
+	option
+}
+
+func NewTCP(host string, subnet *net.IPNet, p proxy.Proxy, opts ...Option) DNS {
+	if subnet == nil {
+		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
+	}
+	if p == nil {
+		p = &proxy.DefaultProxy{}
+	}
+	return &tcp{
+		host:   withPort(host, "53"),
+		subnet: subnet,
+		proxy:  p,
+		option: newOption(opts),
+	}
+}
+
+func (t *tcp) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := t.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, t.subnet, t.strategy, t.exchange)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tcp resolve domain %s failed: %w", domain, err)
+	}
+	return ips, nil
+}
+
+func (t *tcp) Do(req []byte) ([]byte, error) {
+	return t.hostsDo(req, t.exchange)
+}
+
+func (t *tcp) exchange(req []byte) ([]byte, error) {
+	conn, err := t.proxy.Conn(t.host)
+	if err != nil {
+		return nil, fmt.Errorf("tcp dial failed: %v", err)
+	}
+	defer conn.Close()
+	return tcpDo(conn, req)
+}
+
+func (t *tcp) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return t.proxy.Conn(t.host)
+		},
+	}
+}
+
+//tcpDo send the request with the two bytes length prefix, and read the response
+func tcpDo(conn net.Conn, req []byte) ([]byte, error) {
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err := conn.Write(append([]byte{byte(len(req) >> 8), byte(len(req))}, req...))
+	if err != nil {
+		return nil, fmt.Errorf("write data failed: %v", err)
+	}
+
+	length := make([]byte, 2)
+	if _, err = io.ReadFull(conn, length); err != nil {
+		return nil, fmt.Errorf("read data length from server failed: %v", err)
+	}
+	resp := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err = io.ReadFull(conn, resp); err != nil {
+		return nil, fmt.Errorf("read data from server failed: %v", err)
+	}
+	return resp, nil
+}
+
+//withPort add the default port if the host has no port
+func withPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,36 @@
+package dns
+
+import (
+	"net"
+	"testing"
+)
+
+func TestTCP(t *testing.T) {
+	s, err := NewServer("127.0.0.1:15354", answer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	ips, err := NewTCP("127.0.0.1:15354", nil, nil).LookupIP("www.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(ips)
+	if len(ips) != 2 || !ips[0].Equal(net.ParseIP("1.2.3.4")) || !ips[1].Equal(net.ParseIP("2001:db8::1")) {
+		t.Errorf("want [1.2.3.4 2001:db8::1], got %v", ips)
+	}
+}
+
+func TestWithPort(t *testing.T) {
+	for k, v := range map[string]string{
+		"1.1.1.1":         "1.1.1.1:853",
+		"1.1.1.1:8853":    "1.1.1.1:8853",
+		"dns.google":      "dns.google:853",
+		"2606:4700::1111": "[2606:4700::1111]:853",
+	} {
+		if x := withPort(k, "853"); x != v {
+			t.Errorf("%s: want %s, got %s", k, v, x)
+		}
+	}
+}
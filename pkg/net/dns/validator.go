@@ -0,0 +1,835 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//Security the dnssec status of the answer, https://tools.ietf.org/html/rfc4035#section-4.3
+type Security int
+
+const (
+	//Insecure the answer isn't signed and there is a proof that the zone is unsigned,
+	//or no trust anchor covers the zone
+	Insecure Security = 0
+	//Secure the signatures are verified up to a trust anchor
+	Secure Security = 1
+	//Bogus the answer should be signed but the validation failed
+	Bogus Security = 2
+)
+
+func (s Security) String() string {
+	switch s {
+	case Secure:
+		return "secure"
+	case Bogus:
+		return "bogus"
+	default:
+		return "insecure"
+	}
+}
+
+//ErrBogus the answer failed the dnssec validation
+var ErrBogus = errors.New("dnssec bogus")
+
+//errNotCut the name isn't a zone cut, returned by the ds lookup
+var errNotCut = errors.New("not a zone cut")
+
+//RootAnchors the DS records of the root key signing keys, https://data.iana.org/root-anchors/root-anchors.xml
+var RootAnchors = []string{
+	". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	". IN DS 38696 8 2 683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16",
+}
+
+//Validator validate the answers by the DNSKEY and DS records queried by do
+type Validator struct {
+	do      func([]byte) ([]byte, error)
+	anchors map[string][]*DSRecord
+	// keys the verified keys of the zones, key: zone, value: *zoneKeys
+	keys *utils.LRU
+	now  func() time.Time
+}
+
+type zoneKeys struct {
+	keys   []*DNSKEYRecord
+	sec    Security
+	expire time.Time
+}
+
+//NewValidator create the validator, anchors: the trust anchors in the DS presentation format, RootAnchors if empty
+func NewValidator(do func([]byte) ([]byte, error), anchors ...string) (*Validator, error) {
+	if len(anchors) == 0 {
+		anchors = RootAnchors
+	}
+	v := &Validator{
+		do:      do,
+		anchors: make(map[string][]*DSRecord),
+		keys:    utils.NewLru(256, 0),
+		now:     time.Now,
+	}
+	for _, a := range anchors {
+		if strings.TrimSpace(a) == "" {
+			continue
+		}
+		owner, ds, err := ParseDSText(a)
+		if err != nil {
+			return nil, fmt.Errorf("parse trust anchor failed: %v", err)
+		}
+		v.anchors[owner] = append(v.anchors[owner], ds)
+	}
+	if len(v.anchors) == 0 {
+		return nil, errors.New("no trust anchor")
+	}
+	return v, nil
+}
+
+//Validate validate the response of the request, the request should be created with the DO bit,
+//the error is the reason of the bogus answer
+func (v *Validator) Validate(req, resp []byte) (Security, error) {
+	r, err := ParseResponse(req, resp)
+	if r == nil {
+		return Bogus, err
+	}
+	if _, ok := err.(RCodeError); ok {
+		// no records to validate, the error is returned to the client
+		return Insecure, nil
+	}
+
+	d, authSec, authErr := v.denial(r.Authorities)
+
+	sec := Secure
+	for _, s := range rrsets(r.Answers) {
+		var x Security
+		if len(s.sigs) == 0 {
+			x, err = v.unsignedSecurity(s.name)
+		} else {
+			var sig *RRSIGRecord
+			sig, x, err = v.verify(s)
+			if x == Secure && int(sig.Labels) < len(splitLabels(strings.TrimPrefix(s.name, "*."))) && !d.wildcard(s.name, sig.Labels) {
+				x, err = Bogus, fmt.Errorf("no proof of the wildcard expansion of %s", s.name)
+			}
+		}
+		if x == Bogus {
+			return Bogus, err
+		}
+		if x == Insecure {
+			sec = Insecure
+		}
+	}
+
+	q := r.Question()
+	if rs, _ := r.Records(q.Type); r.RCode == dnsmessage.RCodeSuccess && (len(rs) != 0 || q.Type == CNAME || q.Type == ANY) {
+		return sec, nil
+	}
+
+	// the answer is NXDOMAIN or NODATA for the canonical name
+	chain := r.Chain()
+	name := canonicalName(chain[len(chain)-1].String())
+	switch {
+	case authSec == Insecure:
+		return Insecure, nil
+	case d.empty() && authErr != nil:
+		return Bogus, authErr
+	case d.empty():
+		x, err := v.unsignedSecurity(name)
+		if x == Secure {
+			x = Insecure
+		}
+		return x, err
+	case r.RCode == dnsmessage.RCodeNameError && !d.nxdomain(name):
+		return Bogus, fmt.Errorf("no proof of the nonexistence of %s", name)
+	case r.RCode != dnsmessage.RCodeNameError && !d.nodata(name, q.Type):
+		return Bogus, fmt.Errorf("no proof of the nonexistence of %s %v", name, q.Type)
+	}
+	return sec, nil
+}
+
+//verify verify the signatures of the signed rrset, return the verified signature if secure
+func (v *Validator) verify(s *rrset) (*RRSIGRecord, Security, error) {
+	now := uint32(v.now().Unix())
+	labels := len(splitLabels(strings.TrimPrefix(s.name, "*.")))
+	err := fmt.Errorf("no signature of %s %v is usable", s.name, s.t)
+	for _, sig := range s.sigs {
+		if !isSubdomain(s.name, sig.SignerName) || int(sig.Labels) > labels || (s.t == DS && s.name == sig.SignerName) {
+			continue
+		}
+		if !sig.ValidAt(now) {
+			err = fmt.Errorf("the signature of %s %v is expired or not yet valid", s.name, s.t)
+			continue
+		}
+
+		keys, sec, e := v.zoneKeys(sig.SignerName)
+		if sec == Insecure {
+			return nil, Insecure, nil
+		}
+		if e != nil {
+			err = e
+			continue
+		}
+		for _, k := range keys {
+			if k.Algorithm != sig.Algorithm || k.KeyTag() != sig.KeyTag {
+				continue
+			}
+			if e = sig.Verify(k, s.records); e == nil {
+				return sig, Secure, nil
+			}
+			err = fmt.Errorf("verify %s %v failed: %v", s.name, s.t, e)
+		}
+	}
+	return nil, Bogus, err
+}
+
+//zoneKeys get the verified zone keys of the zone, the keys are cached by the ttl
+func (v *Validator) zoneKeys(zone string) ([]*DNSKEYRecord, Security, error) {
+	zone = canonicalName(zone)
+	if z, ok := v.cachedKeys(zone); ok {
+		return z.keys, z.sec, nil
+	}
+
+	keys, sec, ttl, err := v.fetchKeys(zone)
+	if err != nil {
+		return nil, Bogus, err
+	}
+	v.cacheKeys(zone, keys, sec, ttl)
+	return keys, sec, nil
+}
+
+func (v *Validator) cachedKeys(zone string) (*zoneKeys, bool) {
+	x, ok := v.keys.Load(zone)
+	if !ok {
+		return nil, false
+	}
+	if z := x.(*zoneKeys); v.now().Before(z.expire) {
+		return z, true
+	}
+	v.keys.Delete(zone)
+	return nil, false
+}
+
+//cacheKeys the ttl is clamped to [1 minute, 1 hour]
+func (v *Validator) cacheKeys(zone string, keys []*DNSKEYRecord, sec Security, ttl uint32) {
+	d := time.Duration(ttl) * time.Second
+	if d < time.Minute {
+		d = time.Minute
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	v.keys.Add(zone, &zoneKeys{keys: keys, sec: sec, expire: v.now().Add(d)})
+}
+
+func (v *Validator) fetchKeys(zone string) ([]*DNSKEYRecord, Security, uint32, error) {
+	if _, ok := v.anchor(zone); !ok {
+		return nil, Insecure, math.MaxUint32, nil
+	}
+
+	ttl := uint32(math.MaxUint32)
+	ds, ok := v.anchors[zone]
+	if !ok {
+		var sec Security
+		var err error
+		ds, sec, ttl, err = v.ds(zone, true)
+		if err == errNotCut {
+			return nil, Bogus, 0, fmt.Errorf("the signer %s isn't a zone", zone)
+		}
+		if sec != Secure {
+			return nil, sec, ttl, err
+		}
+	}
+
+	supported := false
+	for _, x := range ds {
+		supported = supported || (supportedAlgorithm(x.Algorithm) && supportedDigest(x.DigestType))
+	}
+	if !supported {
+		// https://tools.ietf.org/html/rfc4035#section-5.2
+		return nil, Insecure, ttl, nil
+	}
+
+	r, err := v.query(zone, DNSKEY)
+	if err != nil {
+		return nil, Bogus, 0, err
+	}
+	s := findRRset(rrsets(r.Answers), zone, DNSKEY)
+	if s == nil {
+		return nil, Bogus, 0, fmt.Errorf("no dnskey of %s", zone)
+	}
+
+	var keys []*DNSKEYRecord
+	for _, x := range s.records {
+		u, ok := x.Body.(*dnsmessage.UnknownResource)
+		if !ok {
+			continue
+		}
+		if k, err := ParseDNSKEY(u.Data); err == nil && k.ZoneKey() {
+			keys = append(keys, k)
+		}
+	}
+
+	now := uint32(v.now().Unix())
+	for _, sig := range s.sigs {
+		if sig.SignerName != zone || !sig.ValidAt(now) {
+			continue
+		}
+		for _, k := range keys {
+			if k.Algorithm != sig.Algorithm || k.KeyTag() != sig.KeyTag || !matchDS(ds, zone, k) {
+				continue
+			}
+			if sig.Verify(k, s.records) == nil {
+				if t := s.ttl(); t < ttl {
+					ttl = t
+				}
+				return keys, Secure, ttl, nil
+			}
+		}
+	}
+	return nil, Bogus, 0, fmt.Errorf("no dnskey of %s matches the ds", zone)
+}
+
+func matchDS(ds []*DSRecord, zone string, k *DNSKEYRecord) bool {
+	for _, x := range ds {
+		if x.Match(zone, k) {
+			return true
+		}
+	}
+	return false
+}
+
+//ds get the verified DS records of the zone, Insecure if there is a proof of no DS,
+//errNotCut if there is a proof of no delegation at the zone,
+//fallback: check the ancestors if the response isn't signed
+func (v *Validator) ds(zone string, fallback bool) ([]*DSRecord, Security, uint32, error) {
+	r, err := v.query(zone, DS)
+	if err != nil {
+		return nil, Bogus, 0, err
+	}
+	if s := findRRset(rrsets(r.Answers), zone, DS); s != nil {
+		_, sec, err := v.verify(s)
+		if sec != Secure {
+			return nil, sec, s.ttl(), err
+		}
+		var ds []*DSRecord
+		for _, x := range s.records {
+			if u, ok := x.Body.(*dnsmessage.UnknownResource); ok {
+				if d, err := ParseDS(u.Data); err == nil {
+					ds = append(ds, d)
+				}
+			}
+		}
+		return ds, Secure, s.ttl(), nil
+	}
+
+	ttl := minTTL(r.Authorities)
+	d, sec, err := v.denial(r.Authorities)
+	switch {
+	case sec == Insecure:
+		return nil, Insecure, ttl, nil
+	case d.empty() && err != nil:
+		return nil, Bogus, 0, err
+	case d.empty() && fallback:
+		sec, err = v.unsignedSecurity(zone)
+		return nil, sec, ttl, err
+	case d.empty():
+		return nil, Bogus, 0, fmt.Errorf("no signed denial of the ds of %s", zone)
+	}
+
+	types, ok := d.types(zone)
+	switch {
+	case ok && hasType(types, DS):
+		return nil, Bogus, 0, fmt.Errorf("the ds of %s is denied by the nsec with ds", zone)
+	case ok && hasType(types, NS) && !hasType(types, SOA):
+		return nil, Insecure, ttl, nil
+	case ok:
+		return nil, Bogus, 0, errNotCut
+	case d.optOut(zone):
+		return nil, Insecure, ttl, nil
+	case d.nxdomain(zone):
+		return nil, Bogus, 0, errNotCut
+	}
+	return nil, Bogus, 0, fmt.Errorf("no proof of the nonexistence of the ds of %s", zone)
+}
+
+//unsignedSecurity the security of the unsigned records of name,
+//the DS records are checked from the trust anchor to name, Insecure if there is an insecure delegation
+func (v *Validator) unsignedSecurity(name string) (Security, error) {
+	anchor, ok := v.anchor(name)
+	if !ok {
+		return Insecure, nil
+	}
+
+	labels := splitLabels(name)
+	for i := len(labels) - len(splitLabels(anchor)) - 1; i >= 0; i-- {
+		zone := strings.Join(labels[i:], ".") + "."
+		if _, ok := v.anchors[zone]; ok {
+			continue
+		}
+		if z, ok := v.cachedKeys(zone); ok {
+			if z.sec == Insecure {
+				return Insecure, nil
+			}
+			continue
+		}
+		_, sec, ttl, err := v.ds(zone, false)
+		switch {
+		case err == errNotCut:
+		case sec == Insecure:
+			v.cacheKeys(zone, nil, Insecure, ttl)
+			return Insecure, nil
+		case sec == Bogus:
+			return Bogus, err
+		}
+	}
+	return Bogus, fmt.Errorf("the records of %s aren't signed in a signed zone", name)
+}
+
+//anchor the closest trust anchor of name
+func (v *Validator) anchor(name string) (string, bool) {
+	for z := canonicalName(name); ; z = parentName(z) {
+		if _, ok := v.anchors[z]; ok {
+			return z, true
+		}
+		if z == "." {
+			return "", false
+		}
+	}
+}
+
+func (v *Validator) query(name string, t Type) (*Response, error) {
+	req, err := NewDNSSECRequest(name, t)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query %s %v failed: %v", name, t, err)
+	}
+	r, err := ParseResponse(req, resp)
+	if err != nil && err != ErrNoSuchName {
+		return nil, fmt.Errorf("query %s %v failed: %v", name, t, err)
+	}
+	return r, nil
+}
+
+//denial verify the NSEC and NSEC3 records of the authority section,
+//Insecure if any of them is signed by an insecure zone, the error is the last verification error
+func (v *Validator) denial(rs []dnsmessage.Resource) (*denial, Security, error) {
+	d := &denial{}
+	sec := Secure
+	var err error
+	for _, s := range rrsets(rs) {
+		if (s.t != NSEC && s.t != NSEC3) || len(s.sigs) == 0 {
+			continue
+		}
+		sig, x, e := v.verify(s)
+		if x == Insecure {
+			sec = Insecure
+		}
+		if x != Secure {
+			if e != nil {
+				err = e
+			}
+			continue
+		}
+		for _, r := range s.records {
+			d.add(s.name, sig.SignerName, s.t, r)
+		}
+	}
+	return d, sec, err
+}
+
+//rrset the records with the same name and type, and the signatures of them
+type rrset struct {
+	name    string
+	t       Type
+	records []dnsmessage.Resource
+	sigs    []*RRSIGRecord
+}
+
+func (s *rrset) ttl() uint32 {
+	ttl := minTTL(s.records)
+	for _, sig := range s.sigs {
+		if sig.OriginalTTL < ttl {
+			ttl = sig.OriginalTTL
+		}
+	}
+	return ttl
+}
+
+func minTTL(rs []dnsmessage.Resource) uint32 {
+	ttl := uint32(math.MaxUint32)
+	for _, r := range rs {
+		if r.Header.TTL < ttl {
+			ttl = r.Header.TTL
+		}
+	}
+	return ttl
+}
+
+//rrsets group the records by the name and type, the OPT records are ignored
+func rrsets(rs []dnsmessage.Resource) []*rrset {
+	var sets []*rrset
+	var sigs []dnsmessage.Resource
+	for _, r := range rs {
+		switch r.Header.Type {
+		case OPT:
+		case RRSIG:
+			sigs = append(sigs, r)
+		default:
+			name := canonicalName(r.Header.Name.String())
+			s := findRRset(sets, name, r.Header.Type)
+			if s == nil {
+				s = &rrset{name: name, t: r.Header.Type}
+				sets = append(sets, s)
+			}
+			s.records = append(s.records, r)
+		}
+	}
+
+	for _, r := range sigs {
+		u, ok := r.Body.(*dnsmessage.UnknownResource)
+		if !ok {
+			continue
+		}
+		sig, err := ParseRRSIG(u.Data)
+		if err != nil {
+			continue
+		}
+		if s := findRRset(sets, canonicalName(r.Header.Name.String()), sig.TypeCovered); s != nil {
+			s.sigs = append(s.sigs, sig)
+		}
+	}
+	return sets
+}
+
+func findRRset(sets []*rrset, name string, t Type) *rrset {
+	for _, s := range sets {
+		if s.t == t && s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+//denial the verified NSEC and NSEC3 records
+type denial struct {
+	nsec  []nsec
+	nsec3 []nsec3
+}
+
+type nsec struct {
+	owner string
+	// zone the signer of the record
+	zone string
+	*NSECRecord
+}
+
+type nsec3 struct {
+	zone string
+	hash []byte
+	*NSEC3Record
+}
+
+func (d *denial) add(owner, zone string, t Type, r dnsmessage.Resource) {
+	u, ok := r.Body.(*dnsmessage.UnknownResource)
+	if !ok {
+		return
+	}
+	switch t {
+	case NSEC:
+		if x, err := ParseNSEC(u.Data); err == nil {
+			d.nsec = append(d.nsec, nsec{owner, zone, x})
+		}
+	case NSEC3:
+		labels := splitLabels(owner)
+		if len(labels) == 0 {
+			return
+		}
+		hash, err := nsec3Encoding.DecodeString(strings.ToUpper(labels[0]))
+		if err != nil || parentName(owner) != zone {
+			return
+		}
+		if x, err := ParseNSEC3(u.Data); err == nil {
+			d.nsec3 = append(d.nsec3, nsec3{parentName(owner), hash, x})
+		}
+	}
+}
+
+func (d *denial) empty() bool { return len(d.nsec) == 0 && len(d.nsec3) == 0 }
+
+//types the types of the NSEC or NSEC3 record matching name
+func (d *denial) types(name string) ([]Type, bool) {
+	for _, x := range d.nsec {
+		if x.owner == canonicalName(name) {
+			return x.Types, true
+		}
+	}
+	if x := d.nsec3Match(name); x != nil {
+		return x.Types, true
+	}
+	return nil, false
+}
+
+//nodata https://tools.ietf.org/html/rfc4035#section-5.4, https://tools.ietf.org/html/rfc5155#section-8.5
+func (d *denial) nodata(name string, t Type) bool {
+	if types, ok := d.types(name); ok {
+		return !hasType(types, t) && !hasType(types, CNAME)
+	}
+	return t == DS && d.optOut(name)
+}
+
+//nxdomain the name and the wildcard of the closest encloser don't exist
+func (d *denial) nxdomain(name string) bool {
+	for _, x := range d.nsec {
+		if !x.covers(name) {
+			continue
+		}
+		ce := commonAncestor(name, x.owner)
+		if c := commonAncestor(name, x.NextDomain); len(c) > len(ce) {
+			ce = c
+		}
+		for _, y := range d.nsec {
+			if y.covers("*." + ce) {
+				return true
+			}
+		}
+	}
+
+	ce, next, ok := d.closestEncloser(name)
+	return ok && d.nsec3Cover(next) != nil && d.nsec3Cover("*."+ce) != nil
+}
+
+//optOut the next closer name of name is covered by an opt-out NSEC3
+func (d *denial) optOut(name string) bool {
+	_, next, ok := d.closestEncloser(name)
+	if !ok {
+		return false
+	}
+	x := d.nsec3Cover(next)
+	return x != nil && x.OptOut()
+}
+
+//wildcard the name expanded from the wildcard with labels doesn't exist
+func (d *denial) wildcard(name string, labels uint8) bool {
+	for _, x := range d.nsec {
+		if x.covers(name) {
+			return true
+		}
+	}
+	l := splitLabels(name)
+	if int(labels)+1 > len(l) {
+		return false
+	}
+	return d.nsec3Cover(strings.Join(l[len(l)-int(labels)-1:], ".")+".") != nil
+}
+
+//closestEncloser the closest existing ancestor of name and the next closer name,
+//https://tools.ietf.org/html/rfc5155#section-8.3
+func (d *denial) closestEncloser(name string) (string, string, bool) {
+	next := canonicalName(name)
+	for ce := parentName(next); ; next, ce = ce, parentName(ce) {
+		if d.nsec3Match(ce) != nil {
+			return ce, next, true
+		}
+		if ce == "." {
+			return "", "", false
+		}
+	}
+}
+
+func (d *denial) nsec3Match(name string) *nsec3 {
+	for i := range d.nsec3 {
+		x := &d.nsec3[i]
+		if !isSubdomain(name, x.zone) {
+			continue
+		}
+		if h, err := x.Hash(name); err == nil && bytes.Equal(h, x.hash) {
+			return x
+		}
+	}
+	return nil
+}
+
+func (d *denial) nsec3Cover(name string) *nsec3 {
+	for i := range d.nsec3 {
+		x := &d.nsec3[i]
+		if !isSubdomain(name, x.zone) {
+			continue
+		}
+		h, err := x.Hash(name)
+		if err != nil {
+			continue
+		}
+		if between(bytes.Compare(x.hash, h), bytes.Compare(h, x.NextHashed), bytes.Compare(x.NextHashed, x.hash)) {
+			return x
+		}
+	}
+	return nil
+}
+
+func (x nsec) covers(name string) bool {
+	return isSubdomain(name, x.zone) && between(canonicalCompare(x.owner, name), canonicalCompare(name, x.NextDomain), canonicalCompare(x.NextDomain, x.owner))
+}
+
+//between whether owner < name < next, the next of the last record is the first, a: owner vs name, b: name vs next, c: next vs owner
+func between(a, b, c int) bool {
+	if c > 0 {
+		return a < 0 && b < 0
+	}
+	return a < 0 || b < 0
+}
+
+//commonAncestor the longest common ancestor of a and b
+func commonAncestor(a, b string) string {
+	x, y := splitLabels(a), splitLabels(b)
+	i := 0
+	for i < len(x) && i < len(y) && x[len(x)-1-i] == y[len(y)-1-i] {
+		i++
+	}
+	if i == 0 {
+		return "."
+	}
+	return strings.Join(x[len(x)-i:], ".") + "."
+}
+
+var _ DNS = (*dnssec)(nil)
+
+//dnssec validate the answers of the dns
+type dnssec struct {
+	dns       DNS
+	validator *Validator
+	subnet    *net.IPNet
+	option
+}
+
+//NewDNSSEC create the dns validating the answers of d, the bogus answers are refused,
+//anchors: the trust anchors in the DS presentation format, RootAnchors if empty
+func NewDNSSEC(d DNS, subnet *net.IPNet, anchors []string, opts ...Option) (DNS, error) {
+	if subnet == nil {
+		_, subnet, _ = net.ParseCIDR("0.0.0.0/0")
+	}
+	v, err := NewValidator(d.Do, anchors...)
+	if err != nil {
+		return nil, err
+	}
+	return &dnssec{dns: d, validator: v, subnet: subnet, option: newOption(opts)}, nil
+}
+
+func (d *dnssec) LookupIP(domain string) ([]net.IP, error) {
+	ips, err := d.lookup(domain, func(domain string) ([]net.IP, uint32, error) {
+		return lookupIP(domain, d.subnet, d.strategy, d.exchange)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dnssec resolve domain %s failed: %w", domain, err)
+	}
+	return ips, nil
+}
+
+func (d *dnssec) Do(req []byte) ([]byte, error) {
+	return d.hostsDo(req, d.exchange)
+}
+
+//exchange the DO and CD bits are set to get the dnssec records, the AD bit of the response is set if it is secure,
+//the dnssec records are removed if the request hasn't the DO bit
+func (d *dnssec) exchange(req []byte) ([]byte, error) {
+	q, dnssecOK, err := setDO(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.dns.Do(q)
+	if err != nil {
+		return nil, err
+	}
+
+	sec, err := d.validator.Validate(q, resp)
+	if sec == Bogus {
+		return nil, fmt.Errorf("%w: %v", ErrBogus, err)
+	}
+
+	if !dnssecOK {
+		if resp, err = stripDNSSEC(resp); err != nil {
+			return nil, err
+		}
+	}
+	if sec == Secure {
+		resp[3] |= adBit
+	} else {
+		resp[3] &^= adBit
+	}
+	return resp, nil
+}
+
+func (d *dnssec) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			return &doConn{do: d.Do}, nil
+		},
+	}
+}
+
+//setDO set the DO bit of the OPT record and the CD bit of the request, return whether the DO bit is set before
+func setDO(req []byte) ([]byte, bool, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		return nil, false, fmt.Errorf("parse request failed: %v", err)
+	}
+
+	dnssecOK := false
+	i := 0
+	for i < len(msg.Additionals) && msg.Additionals[i].Header.Type != OPT {
+		i++
+	}
+	if i == len(msg.Additionals) {
+		msg.Additionals = append(msg.Additionals, dnsmessage.Resource{Body: &dnsmessage.OPTResource{}})
+		msg.Additionals[i].Header.Class = 4096
+	} else {
+		dnssecOK = msg.Additionals[i].Header.DNSSECAllowed()
+	}
+	h := &msg.Additionals[i].Header
+	if err := h.SetEDNS0(int(h.Class), dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, false, err
+	}
+
+	b, err := msg.Pack()
+	if err != nil {
+		return nil, false, err
+	}
+	b[3] |= cdBit
+	return b, dnssecOK, nil
+}
+
+//stripDNSSEC remove the dnssec records which are not asked, https://tools.ietf.org/html/rfc4035#section-3.2.1
+func stripDNSSEC(resp []byte) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(resp); err != nil {
+		return nil, fmt.Errorf("parse response failed: %v", err)
+	}
+	var t Type
+	if len(msg.Questions) != 0 {
+		t = msg.Questions[0].Type
+	}
+	strip := func(rs []dnsmessage.Resource) []dnsmessage.Resource {
+		x := rs[:0]
+		for _, r := range rs {
+			switch r.Header.Type {
+			case RRSIG, NSEC, NSEC3, DNSKEY, DS:
+				if r.Header.Type != t {
+					continue
+				}
+			}
+			x = append(x, r)
+		}
+		return x
+	}
+	msg.Answers, msg.Authorities = strip(msg.Answers), strip(msg.Authorities)
+	return msg.Pack()
+}
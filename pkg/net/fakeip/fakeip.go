@@ -0,0 +1,44 @@
+package fakeip
+
+import (
+	"net"
+	"sync"
+)
+
+var (
+	current     *Pool
+	currentLock sync.RWMutex
+)
+
+//SetPool set the pool used by Addr, nil to disable the reverse mapping
+func SetPool(p *Pool) {
+	currentLock.Lock()
+	defer currentLock.Unlock()
+	current = p
+}
+
+//Addr reverse map the fake ip of addr(ip:port) to domain:port,
+//return addr and false if the ip is not a fake ip or the entry is expired
+func Addr(addr string) (string, bool) {
+	currentLock.RLock()
+	p := current
+	currentLock.RUnlock()
+	if p == nil {
+		return addr, false
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return addr, false
+	}
+
+	domain, ok := p.Domain(ip)
+	if !ok {
+		return addr, false
+	}
+	return net.JoinHostPort(domain, port), true
+}
@@ -0,0 +1,120 @@
+package fakeip
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestPool(t *testing.T) {
+	p, err := NewPool("10.0.0.0/30", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := p.Get("a.com")
+	b := p.Get("B.com.")
+	t.Log(a, b)
+	if !a.Equal(net.ParseIP("10.0.0.1")) || !b.Equal(net.ParseIP("10.0.0.2")) {
+		t.Error("unexpected ip", a, b)
+	}
+	if x := p.Get("a.com"); !x.Equal(a) {
+		t.Error("the ip of a.com changed", x)
+	}
+
+	if d, ok := p.Domain(b); !ok || d != "b.com" {
+		t.Error("reverse lookup failed", d, ok)
+	}
+
+	// the pool is full, reuse the least recently used b.com
+	_, _ = p.Domain(a)
+	c := p.Get("c.com")
+	if !c.Equal(b) {
+		t.Error("c.com should reuse the ip of b.com", c)
+	}
+	if _, ok := p.Domain(net.ParseIP("10.0.0.3")); ok {
+		t.Error("the broadcast address should not be used")
+	}
+	if d, _ := p.Domain(c); d != "c.com" || p.Len() != 2 {
+		t.Error("unexpected table", d, p.Len())
+	}
+}
+
+func TestPoolExpire(t *testing.T) {
+	p, err := NewPool("", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip := p.Get("a.com")
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := p.Domain(ip); ok {
+		t.Error("the entry should be expired")
+	}
+
+	if _, err = NewPool("fd00::/64", 0); err == nil {
+		t.Error("ipv6 pool should be rejected")
+	}
+}
+
+func TestAddr(t *testing.T) {
+	p, _ := NewPool("", 0)
+	ip := p.Get("www.example.com")
+
+	SetPool(p)
+	defer SetPool(nil)
+
+	addr, ok := Addr(net.JoinHostPort(ip.String(), "443"))
+	if !ok || addr != "www.example.com:443" {
+		t.Error("unexpected addr", addr, ok)
+	}
+	if addr, ok = Addr("1.1.1.1:53"); ok || addr != "1.1.1.1:53" {
+		t.Error("unexpected addr", addr, ok)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	p, _ := NewPool("", 0)
+	r := &Resolver{Pool: p, Exclude: func(s string) bool { return s == "lan" }}
+
+	query := func(name string, typ dnsmessage.Type) dnsmessage.Message {
+		msg := dnsmessage.Message{
+			Header: dnsmessage.Header{ID: 0x1234, RecursionDesired: true},
+			Questions: []dnsmessage.Question{
+				{Name: dnsmessage.MustNewName(name), Type: typ, Class: dnsmessage.ClassINET},
+			},
+		}
+		req, err := msg.Pack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := r.Handle(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp dnsmessage.Message
+		if err = resp.Unpack(b); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := query("www.example.com.", dnsmessage.TypeA)
+	if resp.ID != 0x1234 || len(resp.Answers) != 1 {
+		t.Fatal("unexpected response", resp)
+	}
+	a := resp.Answers[0].Body.(*dnsmessage.AResource).A
+	if d, ok := p.Domain(net.IP(a[:])); !ok || d != "www.example.com" {
+		t.Error("the answer is not in the table", a, d)
+	}
+
+	if resp = query("www.example.com.", dnsmessage.TypeAAAA); resp.RCode != dnsmessage.RCodeSuccess || len(resp.Answers) != 0 {
+		t.Error("AAAA should get empty answer", resp)
+	}
+
+	// no upstream
+	if resp = query("lan.", dnsmessage.TypeA); resp.RCode != dnsmessage.RCodeServerFailure {
+		t.Error("excluded domain should not get fake ip", resp)
+	}
+}
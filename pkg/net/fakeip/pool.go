@@ -0,0 +1,153 @@
+package fakeip
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+//DefaultPool the default fake ip range, reserved for the benchmark testing
+const DefaultPool = "198.18.0.0/15"
+
+//Pool the bidirectional domain<->ip table, the entries expire after ttl since the last use,
+//when the pool is full, the least recently used entry is reused
+type Pool struct {
+	ipNet *net.IPNet
+	first uint32 // the first usable ip
+	size  uint32 // the count of the usable ips
+	next  uint32 // the offset of the next never used ip
+	ttl   time.Duration
+
+	lock    sync.Mutex
+	domains map[string]*list.Element
+	ips     map[uint32]*list.Element
+	lru     *list.List // front is the recently used
+}
+
+type entry struct {
+	domain string
+	ip     uint32
+	expire time.Time
+}
+
+//NewPool create the pool from an ipv4 cidr, ttl <= 0 means never expire
+func NewPool(cidr string, ttl time.Duration) (*Pool, error) {
+	if cidr == "" {
+		cidr = DefaultPool
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("parse fake ip cidr failed: %v", err)
+	}
+	if ipNet.IP.To4() == nil {
+		return nil, fmt.Errorf("fake ip pool only support ipv4: %s", cidr)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones < 2 {
+		return nil, fmt.Errorf("fake ip pool %s is too small", cidr)
+	}
+
+	return &Pool{
+		ipNet:   ipNet,
+		first:   binary.BigEndian.Uint32(ipNet.IP.To4()) + 1, // skip the network address
+		size:    1<<uint(bits-ones) - 2,                      // without the network and broadcast address
+		ttl:     ttl,
+		domains: make(map[string]*list.Element),
+		ips:     make(map[uint32]*list.Element),
+		lru:     list.New(),
+	}, nil
+}
+
+//Contains whether the ip is in the pool
+func (p *Pool) Contains(ip net.IP) bool {
+	return p.ipNet.Contains(ip)
+}
+
+//Get get the fake ip of the domain, allocate a new one if not exist
+func (p *Pool) Get(domain string) net.IP {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if e, ok := p.domains[domain]; ok {
+		p.touch(e)
+		return toIP(e.Value.(*entry).ip)
+	}
+
+	var ip uint32
+	if p.next < p.size {
+		ip = p.first + p.next
+		p.next++
+	} else {
+		// the oldest entry is expired or the least recently used
+		e := p.lru.Back()
+		x := e.Value.(*entry)
+		ip = x.ip
+		p.remove(e)
+	}
+
+	x := &entry{domain: domain, ip: ip}
+	e := p.lru.PushFront(x)
+	p.touch(e)
+	p.domains[domain] = e
+	p.ips[ip] = e
+	return toIP(ip)
+}
+
+//Domain get the domain of the fake ip, the expired entry is removed
+func (p *Pool) Domain(ip net.IP) (string, bool) {
+	ip4 := ip.To4()
+	if ip4 == nil || !p.ipNet.Contains(ip4) {
+		return "", false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e, ok := p.ips[binary.BigEndian.Uint32(ip4)]
+	if !ok {
+		return "", false
+	}
+
+	x := e.Value.(*entry)
+	if p.ttl > 0 && time.Now().After(x.expire) {
+		p.remove(e)
+		return "", false
+	}
+
+	p.touch(e)
+	return x.domain, true
+}
+
+//Len the count of the entries in the table
+func (p *Pool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.lru.Len()
+}
+
+func (p *Pool) touch(e *list.Element) {
+	if p.ttl > 0 {
+		e.Value.(*entry).expire = time.Now().Add(p.ttl)
+	}
+	p.lru.MoveToFront(e)
+}
+
+func (p *Pool) remove(e *list.Element) {
+	x := e.Value.(*entry)
+	delete(p.domains, x.domain)
+	delete(p.ips, x.ip)
+	p.lru.Remove(e)
+}
+
+func toIP(i uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, i)
+	return ip
+}
@@ -0,0 +1,96 @@
+package fakeip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+//TTL the ttl of the fake answers, seconds, keep it far less than the ttl of the pool
+const TTL = 60
+
+//Resolver answer the A queries with the fake ips,
+//the excluded domains are resolved by the upstream,
+//the AAAA and other queries get empty answers, so the clients fall back to ipv4
+type Resolver struct {
+	Pool     *Pool
+	Upstream dns.DNS
+	// Exclude return true if the domain should be resolved by the upstream, can be nil
+	Exclude func(domain string) bool
+}
+
+//Handle handle the dns request message, return the response message
+func (r *Resolver) Handle(req []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	h, err := p.Start(req)
+	if err != nil {
+		return nil, fmt.Errorf("parse dns header failed: %v", err)
+	}
+	q, err := p.Question()
+	if err != nil {
+		return reply(h, nil, dnsmessage.RCodeFormatError, nil)
+	}
+
+	domain := strings.ToLower(strings.TrimSuffix(q.Name.String(), "."))
+	if q.Type != dnsmessage.TypeA || q.Class != dnsmessage.ClassINET {
+		return reply(h, &q, dnsmessage.RCodeSuccess, nil)
+	}
+
+	if r.Exclude != nil && r.Exclude(domain) {
+		if r.Upstream == nil {
+			return reply(h, &q, dnsmessage.RCodeServerFailure, nil)
+		}
+		ips, err := r.Upstream.LookupIP(domain)
+		if err != nil {
+			return reply(h, &q, dnsmessage.RCodeServerFailure, nil)
+		}
+		var as []dnsmessage.AResource
+		for _, ip := range ips {
+			if ip4 := ip.To4(); ip4 != nil {
+				a := dnsmessage.AResource{}
+				copy(a.A[:], ip4)
+				as = append(as, a)
+			}
+		}
+		return reply(h, &q, dnsmessage.RCodeSuccess, as)
+	}
+
+	a := dnsmessage.AResource{}
+	copy(a.A[:], r.Pool.Get(domain).To4())
+	return reply(h, &q, dnsmessage.RCodeSuccess, []dnsmessage.AResource{a})
+}
+
+func reply(req dnsmessage.Header, q *dnsmessage.Question, rcode dnsmessage.RCode, as []dnsmessage.AResource) ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:                 req.ID,
+		Response:           true,
+		OpCode:             req.OpCode,
+		RecursionDesired:   req.RecursionDesired,
+		RecursionAvailable: true,
+		RCode:              rcode,
+	})
+	b.EnableCompression()
+
+	if q == nil {
+		return b.Finish()
+	}
+
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(*q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	for i := range as {
+		err := b.AResource(dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: TTL}, as[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish()
+}
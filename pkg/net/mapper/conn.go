@@ -0,0 +1,133 @@
+package mapper
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+//Owner get the process name and uid of the connection owner for the process rules, ok is false if not found
+type Owner func() (name string, uid uint32, ok bool)
+
+//connRule match the connection but not the host, eg:
+//	port:22, port:1000-2000, DST-PORT,22
+//	tcp:22, udp:443, udp:1000-2000
+//	network:udp, NETWORK,UDP
+//	process:curl, PROCESS-NAME,curl
+//	uid:1000, UID,1000
+type connRule struct {
+	network  string // tcp, udp, empty for all
+	min, max uint16 // 0-65535 for all
+	process  string
+	uid      int64 // -1 for all
+	index    int
+	mark     interface{}
+}
+
+type connInfo struct {
+	network string
+	port    uint16
+	owner   Owner
+
+	resolved bool
+	ok       bool
+	name     string
+	uid      uint32
+}
+
+//process call the owner once
+func (c *connInfo) process() (string, uint32, bool) {
+	if !c.resolved {
+		c.resolved = true
+		if c.owner != nil {
+			c.name, c.uid, c.ok = c.owner()
+		}
+	}
+	return c.name, c.uid, c.ok
+}
+
+func (p *connRule) match(c *connInfo) bool {
+	if p.network != "" && !strings.HasPrefix(c.network, p.network) { // tcp4, tcp6, udp4, udp6
+		return false
+	}
+	if c.port < p.min || c.port > p.max {
+		return false
+	}
+	if p.process == "" && p.uid == -1 {
+		return true
+	}
+
+	name, uid, ok := c.process()
+	if !ok {
+		return false
+	}
+	if p.process != "" && p.process != name {
+		return false
+	}
+	return p.uid == -1 || p.uid == int64(uid)
+}
+
+//parseConnRule return false if str is not a connection rule
+func parseConnRule(str string) (*connRule, bool, error) {
+	var network, ports string
+	switch {
+	case hasRulePrefix(str, "process"), hasRulePrefix(str, "process-name"):
+		return &connRule{process: str[strings.IndexAny(str, ":,")+1:], max: 65535, uid: -1}, true, nil
+	case hasRulePrefix(str, "uid"):
+		uid, err := strconv.ParseUint(str[4:], 10, 32)
+		if err != nil {
+			return nil, true, fmt.Errorf("parse uid %s failed: %v", str[4:], err)
+		}
+		return &connRule{uid: int64(uid), max: 65535}, true, nil
+	case hasRulePrefix(str, "port"), hasRulePrefix(str, "dst-port"):
+		ports = str[strings.IndexAny(str, ":,")+1:]
+	case hasRulePrefix(str, "tcp"), hasRulePrefix(str, "udp"):
+		network, ports = strings.ToLower(str[:3]), str[4:]
+	case hasRulePrefix(str, "network"):
+		network = strings.ToLower(str[8:])
+		if network != "tcp" && network != "udp" {
+			return nil, true, fmt.Errorf("unknown network: %s", str[8:])
+		}
+		return &connRule{network: network, min: 0, max: 65535, uid: -1}, true, nil
+	default:
+		return nil, false, nil
+	}
+
+	r := &connRule{network: network, uid: -1}
+	min, max := ports, ports
+	if i := strings.IndexByte(ports, '-'); i != -1 {
+		min, max = ports[:i], ports[i+1:]
+	}
+
+	a, err := strconv.ParseUint(min, 10, 16)
+	if err != nil {
+		return nil, true, fmt.Errorf("parse port %s failed: %v", min, err)
+	}
+	b, err := strconv.ParseUint(max, 10, 16)
+	if err != nil {
+		return nil, true, fmt.Errorf("parse port %s failed: %v", max, err)
+	}
+	if a > b {
+		return nil, true, fmt.Errorf("invalid port range: %s", ports)
+	}
+	r.min, r.max = uint16(a), uint16(b)
+	return r, true, nil
+}
+
+//hasRulePrefix the case-insensitive prefix followed by ':' or ','
+func hasRulePrefix(str, prefix string) bool {
+	return len(str) > len(prefix)+1 &&
+		strings.EqualFold(str[:len(prefix)], prefix) &&
+		(str[len(prefix)] == ':' || str[len(prefix)] == ',')
+}
+
+//splitAddr split host:port, the port is 0 if addr has no port
+func splitAddr(addr string) (string, uint16) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, 0
+	}
+	p, _ := strconv.ParseUint(port, 10, 16)
+	return host, uint16(p)
+}
@@ -0,0 +1,115 @@
+package mapper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper/geodata"
+	"github.com/oschwald/maxminddb-golang"
+	"google.golang.org/protobuf/proto"
+)
+
+//GeoIP country database
+type GeoIP interface {
+	// Lookup return the upper case country codes that ip belongs to
+	Lookup(ip net.IP) []string
+}
+
+//NewGeoIP open a MaxMind mmdb or a v2ray geoip.dat file,
+//for geoip.dat only the countries given are loaded, all countries are loaded if countries is empty
+func NewGeoIP(file string, countries ...string) (GeoIP, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read geoip file failed: %w", err)
+	}
+
+	if r, err := maxminddb.FromBytes(data); err == nil {
+		return &mmdb{r}, nil
+	}
+
+	g, err := newGeoIPDat(data, countries...)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a mmdb nor a geoip.dat file: %v", file, err)
+	}
+	return g, nil
+}
+
+type mmdb struct {
+	reader *maxminddb.Reader
+}
+
+func (m *mmdb) Lookup(ip net.IP) []string {
+	var r struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+		RegisteredCountry struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"registered_country"`
+	}
+	if err := m.reader.Lookup(ip, &r); err != nil {
+		return nil
+	}
+
+	if r.Country.ISOCode != "" {
+		return []string{strings.ToUpper(r.Country.ISOCode)}
+	}
+	if r.RegisteredCountry.ISOCode != "" {
+		return []string{strings.ToUpper(r.RegisteredCountry.ISOCode)}
+	}
+	return nil
+}
+
+//geoIPDat the countries of geoip.dat may overlap (e.g. private and cn), so every country has its own cidr trie
+type geoIPDat struct {
+	countries []geoIPDatCountry
+}
+
+type geoIPDatCountry struct {
+	code    string
+	cidr    *Cidr
+	reverse bool
+}
+
+func newGeoIPDat(data []byte, countries ...string) (*geoIPDat, error) {
+	list := &geodata.GeoIPList{}
+	if err := proto.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("unmarshal geoip.dat failed: %v", err)
+	}
+
+	want := make(map[string]bool, len(countries))
+	for i := range countries {
+		want[strings.ToUpper(countries[i])] = true
+	}
+
+	g := &geoIPDat{}
+	for _, e := range list.Entry {
+		code := strings.ToUpper(e.CountryCode)
+		if len(want) != 0 && !want[code] {
+			continue
+		}
+
+		c := geoIPDatCountry{code: code, cidr: NewCidrMapper(), reverse: e.ReverseMatch}
+		for _, r := range e.Cidr {
+			ip := net.IP(r.Ip)
+			if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+				continue
+			}
+			c.cidr.InsertCIDR(&net.IPNet{IP: ip, Mask: net.CIDRMask(int(r.Prefix), len(ip)*8)}, code)
+		}
+		g.countries = append(g.countries, c)
+	}
+	return g, nil
+}
+
+func (g *geoIPDat) Lookup(ip net.IP) []string {
+	var r []string
+	for i := range g.countries {
+		if _, ok := g.countries[i].cidr.SearchIP(ip); ok != g.countries[i].reverse {
+			r = append(r, g.countries[i].code)
+		}
+	}
+	return r
+}
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper/geodata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGeoIP(t *testing.T) {
+	data, err := proto.Marshal(&geodata.GeoIPList{
+		Entry: []*geodata.GeoIP{
+			{CountryCode: "cn", Cidr: []*geodata.CIDR{{Ip: net.IP{1, 0, 1, 0}, Prefix: 24}}},
+			{CountryCode: "private", Cidr: []*geodata.CIDR{{Ip: net.IP{10, 0, 0, 0}, Prefix: 8}}},
+			{CountryCode: "us", Cidr: []*geodata.CIDR{{Ip: net.ParseIP("2001:db8::"), Prefix: 32}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "geoip.dat")
+	if err = ioutil.WriteFile(file, data, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMapper(func(string) ([]net.IP, error) { return []net.IP{net.ParseIP("1.0.1.1")}, nil })
+	m.Insert("GEOIP,CN", "direct")
+	m.Insert("geoip:us", "proxy")
+	m.Insert("10.0.0.0/8", "cidr")
+
+	g, err := NewGeoIP(file, m.GeoIPCountries()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetGeoIP(g)
+
+	for k, v := range map[string]interface{}{
+		"1.0.1.2":       "direct",
+		"2001:db8::1":   "proxy",
+		"10.1.1.1":      "cidr",
+		"8.8.8.8":       nil,
+		"www.baidu.com": "direct",
+	} {
+		if x := m.Search(k); x != v {
+			t.Errorf("search %s: want %v, got %v", k, v, x)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package mapper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper/geodata"
+	"google.golang.org/protobuf/proto"
+)
+
+//GeoSite the domain lists of v2ray geosite.dat, key is the upper case category
+type GeoSite map[string][]*geodata.Domain
+
+//NewGeoSite read a v2ray geosite.dat file
+func NewGeoSite(file string) (GeoSite, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read geosite file failed: %w", err)
+	}
+
+	list := &geodata.GeoSiteList{}
+	if err = proto.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("unmarshal geosite.dat failed: %v", err)
+	}
+
+	g := make(GeoSite, len(list.Entry))
+	for _, e := range list.Entry {
+		c := strings.ToUpper(e.CountryCode)
+		g[c] = append(g[c], e.Domain...)
+	}
+	return g, nil
+}
+
+//parseGeoSite parse geosite rule, eg: "geosite:cn", "geosite:category-ads-all@ads", "geosite:google@cn@ads"
+func parseGeoSite(str string) (category string, attrs []string, ok bool) {
+	if len(str) <= 8 || !strings.EqualFold(str[:8], "geosite:") {
+		return "", nil, false
+	}
+	s := strings.Split(str[8:], "@")
+	for i := 1; i < len(s); i++ {
+		if s[i] != "" {
+			attrs = append(attrs, strings.ToLower(s[i]))
+		}
+	}
+	return strings.ToUpper(s[0]), attrs, true
+}
+
+func hasAttrs(d *geodata.Domain, attrs []string) bool {
+	for _, a := range attrs {
+		found := false
+		for _, x := range d.Attribute {
+			if strings.EqualFold(x.Key, a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (x *Mapper) insertGeoSite(category string, attrs []string, mark interface{}) {
+	if x.geosite == nil {
+		log.Printf("geosite file is not set, ignore geosite:%s", strings.ToLower(category))
+		return
+	}
+
+	ds, ok := x.geosite[category]
+	if !ok {
+		log.Printf("geosite category %s is not found", strings.ToLower(category))
+		return
+	}
+
+	if x.ordered != nil {
+		x.insertGeoSiteOrdered(ds, attrs, mark)
+		return
+	}
+
+	for _, d := range ds {
+		if !hasAttrs(d, attrs) {
+			continue
+		}
+
+		switch d.Type {
+		case geodata.Domain_Plain:
+			x.keyword.Insert(d.Value, mark)
+		case geodata.Domain_Regex:
+			if err := x.regexp.Insert(d.Value, mark); err != nil {
+				log.Printf("insert geosite regexp %s failed: %v", d.Value, err)
+			}
+		case geodata.Domain_Domain:
+			x.domain.Insert("*."+d.Value, mark)
+		case geodata.Domain_Full:
+			x.full.Insert(d.Value, mark)
+		}
+	}
+}
+
+//insertGeoSiteOrdered all domains of the category share one index
+func (x *Mapper) insertGeoSiteOrdered(ds []*geodata.Domain, attrs []string, mark interface{}) {
+	index := x.ordered.next(mark)
+	for _, d := range ds {
+		if !hasAttrs(d, attrs) {
+			continue
+		}
+
+		switch d.Type {
+		case geodata.Domain_Plain:
+			x.ordered.insertKeyword(d.Value, index)
+		case geodata.Domain_Regex:
+			if err := x.ordered.insertRegexp(d.Value, index); err != nil {
+				log.Printf("insert geosite regexp %s failed: %v", d.Value, err)
+			}
+		case geodata.Domain_Domain:
+			x.ordered.insertSuffix(d.Value, index)
+		case geodata.Domain_Full:
+			x.ordered.insertFull(d.Value, index)
+		}
+	}
+}
+
+//SetGeoSite set the geosite lists for the geosite rules inserted after,
+//it can be set to nil to release the memory after all rules are inserted
+func (x *Mapper) SetGeoSite(g GeoSite) {
+	x.geosite = g
+}
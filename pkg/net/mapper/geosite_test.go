@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/mapper/geodata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGeoSite(t *testing.T) {
+	data, err := proto.Marshal(&geodata.GeoSiteList{
+		Entry: []*geodata.GeoSite{
+			{
+				CountryCode: "TEST",
+				Domain: []*geodata.Domain{
+					{Type: geodata.Domain_Domain, Value: "example.com"},
+					{Type: geodata.Domain_Full, Value: "full.example.org"},
+					{Type: geodata.Domain_Plain, Value: "keyword"},
+					{Type: geodata.Domain_Regex, Value: `^re\d+\.example\.net$`},
+					{
+						Type:      geodata.Domain_Domain,
+						Value:     "ads.example.io",
+						Attribute: []*geodata.Domain_Attribute{{Key: "ads", TypedValue: &geodata.Domain_Attribute_BoolValue{BoolValue: true}}},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "yuhaiin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "geosite.dat")
+	if err = ioutil.WriteFile(file, data, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGeoSite(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMapper(nil)
+	m.SetGeoSite(g)
+	m.Insert("geosite:test", "test")
+	m.Insert("geosite:test@ads", "ads")
+	m.SetGeoSite(nil)
+
+	for k, v := range map[string]interface{}{
+		"example.com":          "test",
+		"www.example.com":      "test",
+		"full.example.org":     "test",
+		"www.full.example.org": nil,
+		"a.keyword.b":          "test",
+		"re12.example.net":     "test",
+		"rex.example.net":      nil,
+		"x.ads.example.io":     "ads",
+		"www.google.com":       nil,
+	} {
+		if x := m.Search(k); x != v {
+			t.Errorf("search %s: want %v, got %v", k, v, x)
+		}
+	}
+}
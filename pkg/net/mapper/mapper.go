@@ -1,32 +1,113 @@
 package mapper
 
 import (
+	"log"
 	"net"
+	"strings"
 	"sync"
 
 	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
 )
 
 type Mapper struct {
-	lookup func(string) ([]net.IP, error)
-	cidr   *Cidr
-	domain *domain
-	cache  *utils.LRU
+	lookup  func(string) ([]net.IP, error)
+	cidr    *Cidr
+	domain  *domain
+	full    full
+	keyword *keyword
+	regexp  *regexps
+	cache   *utils.LRU
+
+	geoip      GeoIP
+	geoipRules []geoipRule
+	geosite    GeoSite
+
+	// ordered is not nil in the ordered mode
+	ordered *ordered
+	conns   []*connRule
 
 	lookupLock sync.RWMutex
 }
 
+type geoipRule struct {
+	country string
+	mark    interface{}
+}
+
 func (x *Mapper) SetLookup(f func(string) ([]net.IP, error)) {
 	x.lookupLock.Lock()
 	defer x.lookupLock.Unlock()
 	x.lookup = f
 }
 
+//Insert insert a rule, the rule can be:
+//	cidr: 10.0.0.0/8
+//	domain: www.example.com, *.example.com, example.*
+//	full:www.example.com, match the whole domain only
+//	domain:example.com, match example.com and all its subdomains
+//	keyword:example, match the domains that contain the keyword
+//	regexp:^ads?\d*\., match the domains that match the regular expression
+//	geoip:cn, GEOIP,CN
+//	geosite:cn, geosite:category-ads-all@ads
+//	port:22, port:1000-2000, tcp:22, udp:443, network:udp, only for SearchAddr
+//	process:curl, uid:1000, only for SearchConn
 func (x *Mapper) Insert(str string, mark interface{}) {
 	if str == "" {
 		return
 	}
 
+	if p, ok, err := parseConnRule(str); ok {
+		if err != nil {
+			log.Printf("insert rule %s failed: %v", str, err)
+			return
+		}
+		p.mark, p.index = mark, -1
+		if x.ordered != nil {
+			p.index = x.ordered.next(mark)
+		}
+		x.conns = append(x.conns, p)
+		return
+	}
+
+	if x.ordered != nil {
+		x.insertOrdered(str, mark)
+		return
+	}
+
+	if s, ok := cutPrefix(str, "full:"); ok {
+		x.full.Insert(s, mark)
+		return
+	}
+
+	if s, ok := cutPrefix(str, "domain:"); ok {
+		if s != "" {
+			x.domain.Insert("*."+s, mark)
+		}
+		return
+	}
+
+	if s, ok := cutPrefix(str, "keyword:"); ok {
+		x.keyword.Insert(s, mark)
+		return
+	}
+
+	if s, ok := cutPrefix(str, "regexp:"); ok {
+		if err := x.regexp.Insert(s, mark); err != nil {
+			log.Printf("insert regexp %s failed: %v", s, err)
+		}
+		return
+	}
+
+	if country, ok := parseGeoIP(str); ok {
+		x.insertGeoIP(country, mark)
+		return
+	}
+
+	if category, attrs, ok := parseGeoSite(str); ok {
+		x.insertGeoSite(category, attrs, mark)
+		return
+	}
+
 	_, ipNet, err := net.ParseCIDR(str)
 	if err != nil {
 		x.domain.Insert(str, mark)
@@ -35,18 +116,46 @@ func (x *Mapper) Insert(str string, mark interface{}) {
 	}
 }
 
+//Result the search result with the details
+type Result struct {
+	Mark interface{}
+	// Cache the result is answered by the lru cache
+	Cache bool
+	// IP the ip used for the cidr and geoip rules, nil if the domain is not resolved
+	IP net.IP
+
+	index int // the index of the matched rule in the ordered mode
+}
+
+//Search search the mark of the domain or ip, the domain is searched in order:
+//full, domain(exact, wildcard), keyword, regexp, then cidr and geoip with the resolved ips, the first matched ip wins
 func (x *Mapper) Search(str string) (mark interface{}) {
+	return x.Explain(str).Mark
+}
+
+//Explain same as Search, but return the details of the result
+func (x *Mapper) Explain(str string) (r Result) {
 	if de, _ := x.cache.Load(str); de != nil {
-		return de
+		if r, ok := de.(Result); ok && r.Mark != nil {
+			r.Cache = true
+			return r
+		}
+	}
+
+	if x.ordered != nil {
+		x.lookupLock.RLock()
+		r.index, r.IP = x.ordered.search(str, x.lookup, x.geoipLookup)
+		x.lookupLock.RUnlock()
+		r.Mark = x.ordered.mark(r.index)
+		goto _end
 	}
 
 	if ip := net.ParseIP(str); ip != nil {
-		mark, _ = x.cidr.SearchIP(ip)
+		r.Mark, r.IP = x.searchIP(ip), ip
 		goto _end
 	}
 
-	mark, _ = x.domain.Search(str)
-	if mark != nil {
+	if r.Mark = x.searchDomain(str); r.Mark != nil {
 		goto _end
 	}
 
@@ -55,26 +164,245 @@ func (x *Mapper) Search(str string) (mark interface{}) {
 	if x.lookup == nil {
 		goto _end
 	}
-	if dns, err := x.lookup(str); err == nil {
-		mark, _ = x.cidr.SearchIP(dns[0])
+	if ips, err := x.lookup(str); err == nil && len(ips) != 0 {
+		r.IP = ips[0]
+		for _, ip := range ips { // the ips are sorted by the address family strategy of the dns
+			if r.Mark = x.searchIP(ip); r.Mark != nil {
+				r.IP = ip
+				break
+			}
+		}
 	}
 
 _end:
-	x.cache.Add(str, mark)
-	return mark
+	x.cache.Add(str, r)
+	return r
+}
+
+//SearchAddr search with the port rules, network is tcp or udp, addr is host:port
+func (x *Mapper) SearchAddr(network, addr string) interface{} {
+	return x.ExplainConn(network, addr, nil).Mark
+}
+
+//ExplainAddr same as SearchAddr, but return the details of the result
+func (x *Mapper) ExplainAddr(network, addr string) Result {
+	return x.ExplainConn(network, addr, nil)
+}
+
+//SearchConn search with the port and process rules, owner is called only when the process rules are evaluated
+func (x *Mapper) SearchConn(network, addr string, owner Owner) interface{} {
+	return x.ExplainConn(network, addr, owner).Mark
+}
+
+//ExplainConn same as SearchConn, but return the details of the result,
+//the port and process rules override the others, but in the ordered mode, the first matched rule wins
+func (x *Mapper) ExplainConn(network, addr string, owner Owner) Result {
+	host, port := splitAddr(addr)
+	c := &connInfo{network: network, port: port, owner: owner}
+
+	if x.ordered == nil {
+		for i := len(x.conns) - 1; i >= 0; i-- { // the later rule override the former
+			if x.conns[i].match(c) {
+				return Result{Mark: x.conns[i].mark}
+			}
+		}
+		return x.Explain(host)
+	}
+
+	// the conn rules before the matched domain rule are checked first,
+	// the host is resolved only if there is an ip rule before the matched conn rule
+	index := -1
+	if net.ParseIP(host) == nil {
+		index = x.ordered.searchDomain(host)
+	}
+	for _, p := range x.conns {
+		if index != -1 && p.index >= index {
+			break
+		}
+		if !p.match(c) {
+			continue
+		}
+		if i := x.ordered.ipMinIndex; i == -1 || p.index < i {
+			return Result{Mark: p.mark}
+		}
+		if r := x.Explain(host); r.Mark != nil && r.index < p.index {
+			return r
+		}
+		return Result{Mark: p.mark}
+	}
+	return x.Explain(host)
+}
+
+//SearchDomain search the mark of the domain by the domain rules only, without the ip, port and process rules,
+//so it never resolves the domain
+func (x *Mapper) SearchDomain(domain string) interface{} {
+	if x.ordered != nil {
+		return x.ordered.mark(x.ordered.searchDomain(domain))
+	}
+	return x.searchDomain(domain)
+}
+
+func (x *Mapper) searchDomain(domain string) interface{} {
+	if mark, ok := x.full.Search(domain); ok {
+		return mark
+	}
+	if mark, ok := x.domain.Search(domain); ok {
+		return mark
+	}
+	if mark, ok := x.keyword.Search(domain); ok {
+		return mark
+	}
+	if mark, ok := x.regexp.Search(domain); ok {
+		return mark
+	}
+	return nil
+}
+
+func (x *Mapper) insertOrdered(str string, mark interface{}) {
+	o := x.ordered
+
+	if category, attrs, ok := parseGeoSite(str); ok {
+		x.insertGeoSite(category, attrs, mark)
+		return
+	}
+
+	index := o.next(mark)
+
+	if country, ok := parseGeoIP(str); ok {
+		o.insertGeoIP(country, index)
+		return
+	}
+	if s, ok := cutPrefix(str, "full:"); ok {
+		o.insertFull(s, index)
+		return
+	}
+	if s, ok := cutPrefix(str, "domain:"); ok {
+		o.insertSuffix(s, index)
+		return
+	}
+	if s, ok := cutPrefix(str, "keyword:"); ok {
+		o.insertKeyword(s, index)
+		return
+	}
+	if s, ok := cutPrefix(str, "regexp:"); ok {
+		if err := o.insertRegexp(s, index); err != nil {
+			log.Printf("insert regexp %s failed: %v", s, err)
+		}
+		return
+	}
+
+	_, ipNet, err := net.ParseCIDR(str)
+	if err != nil {
+		o.insertDomain(str, index)
+	} else {
+		o.insertCIDR(ipNet, index)
+	}
+}
+
+func (x *Mapper) geoipLookup(ip net.IP) []string {
+	if x.geoip == nil {
+		return nil
+	}
+	return x.geoip.Lookup(ip)
+}
+
+//cutPrefix cut the case-insensitive rule prefix, eg: "full:", "keyword:"
+func cutPrefix(str, prefix string) (string, bool) {
+	if len(str) < len(prefix) || !strings.EqualFold(str[:len(prefix)], prefix) {
+		return "", false
+	}
+	return str[len(prefix):], true
+}
+
+func (x *Mapper) searchIP(ip net.IP) interface{} {
+	if mark, ok := x.cidr.SearchIP(ip); ok {
+		return mark
+	}
+
+	if x.geoip == nil || len(x.geoipRules) == 0 {
+		return nil
+	}
+	countries := x.geoip.Lookup(ip)
+	for i := len(x.geoipRules) - 1; i >= 0; i-- { // the later rule override the former
+		for _, c := range countries {
+			if c == x.geoipRules[i].country {
+				return x.geoipRules[i].mark
+			}
+		}
+	}
+	return nil
+}
+
+//parseGeoIP parse geoip rule, eg: "geoip:cn", "GEOIP,CN"
+func parseGeoIP(str string) (string, bool) {
+	if len(str) <= 6 || !strings.EqualFold(str[:5], "geoip") || (str[5] != ':' && str[5] != ',') {
+		return "", false
+	}
+	return strings.ToUpper(str[6:]), true
+}
+
+func (x *Mapper) insertGeoIP(country string, mark interface{}) {
+	for i := range x.geoipRules {
+		if x.geoipRules[i].country == country {
+			x.geoipRules = append(x.geoipRules[:i], x.geoipRules[i+1:]...)
+			break
+		}
+	}
+	x.geoipRules = append(x.geoipRules, geoipRule{country, mark})
+}
+
+//SetGeoIP set the country database for geoip rules
+func (x *Mapper) SetGeoIP(g GeoIP) {
+	x.geoip = g
+	x.cache = utils.NewLru(150, 0)
+}
+
+//GeoIPCountries the countries that used by geoip rules
+func (x *Mapper) GeoIPCountries() []string {
+	if x.ordered != nil {
+		r := make([]string, 0, len(x.ordered.geoip))
+		for i := range x.ordered.geoip {
+			r = append(r, x.ordered.geoip[i].country)
+		}
+		return r
+	}
+
+	r := make([]string, 0, len(x.geoipRules))
+	for i := range x.geoipRules {
+		r = append(r, x.geoipRules[i].country)
+	}
+	return r
 }
 
 func (x *Mapper) Clear() {
 	x.cidr = NewCidrMapper()
 	x.domain = NewDomainMapper()
+	x.full = make(full)
+	x.keyword = &keyword{}
+	x.regexp = &regexps{}
+	x.geoipRules = nil
+	x.conns = nil
+	if x.ordered != nil {
+		x.ordered = newOrdered()
+	}
 	x.cache = utils.NewLru(150, 0)
 }
 
 func NewMapper(lookup func(string) ([]net.IP, error)) (matcher *Mapper) {
 	return &Mapper{
-		cidr:   NewCidrMapper(),
-		domain: NewDomainMapper(),
-		cache:  utils.NewLru(150, 0),
-		lookup: lookup,
+		cidr:    NewCidrMapper(),
+		domain:  NewDomainMapper(),
+		full:    make(full),
+		keyword: &keyword{},
+		regexp:  &regexps{},
+		cache:   utils.NewLru(150, 0),
+		lookup:  lookup,
 	}
 }
+
+//NewOrderedMapper create a mapper that evaluates the rules in insertion order, the first matched rule wins
+func NewOrderedMapper(lookup func(string) ([]net.IP, error)) *Mapper {
+	m := NewMapper(lookup)
+	m.ordered = newOrdered()
+	return m
+}
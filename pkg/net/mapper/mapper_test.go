@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"net"
 	"testing"
 
 	"github.com/Asutorufa/yuhaiin/pkg/net/dns"
@@ -40,3 +41,208 @@ func BenchmarkMapper(b *testing.B) {
 		}
 	}
 }
+
+func TestMapperPrefix(t *testing.T) {
+	matcher := NewMapper(nil)
+	matcher.Insert("keyword:google", "keyword")
+	matcher.Insert("regexp:^ads?\\d*\\.", "regexp")
+	matcher.Insert("full:www.google.com", "full")
+	matcher.Insert("domain:example.com", "domain")
+	matcher.Insert("*.google.cn", "wildcard")
+	matcher.Insert("regexp:[", "invalid")
+
+	for k, v := range map[string]interface{}{
+		"www.google.com":      "full",
+		"mail.google.com":     "keyword",
+		"www.google.cn":       "wildcard",
+		"example.com":         "domain",
+		"a.b.example.com":     "domain",
+		"ad1.example.org":     "regexp",
+		"ads.google.com":      "keyword",
+		"notexample.com":      nil,
+		"www.google.com.evil": "keyword",
+	} {
+		if x := matcher.Search(k); x != v {
+			t.Errorf("search %s: want %v, got %v", k, v, x)
+		}
+	}
+}
+
+func TestOrderedMapper(t *testing.T) {
+	lookup := 0
+	matcher := NewOrderedMapper(func(string) ([]net.IP, error) {
+		lookup++
+		return []net.IP{net.ParseIP("10.2.2.1")}, nil
+	})
+	matcher.Insert("*.baidu.com", "wildcard")
+	matcher.Insert("www.baidu.com", "exact")
+	matcher.Insert("example.*", "label")
+	matcher.Insert("10.0.0.0/8", "cidr8")
+	matcher.Insert("10.2.0.0/16", "cidr16")
+	matcher.Insert("keyword:google", "keyword")
+
+	for k, v := range map[string]interface{}{
+		"www.baidu.com":   "wildcard",
+		"baidu.com":       "wildcard",
+		"10.2.2.1":        "cidr8",
+		"www.google.com":  "cidr8",
+		"example.org":     "label",
+		"www.example.org": "cidr8",
+		"ff::":            nil,
+	} {
+		if x := matcher.Search(k); x != v {
+			t.Errorf("search %s: want %v, got %v", k, v, x)
+		}
+	}
+
+	lookup = 0
+	matcher.Search("tieba.baidu.com")
+	if lookup != 0 {
+		t.Error("lookup is unnecessary when a domain rule before all ip rules matched")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	matcher := NewMapper(func(string) ([]net.IP, error) { return []net.IP{net.ParseIP("10.2.2.1")}, nil })
+	matcher.Insert("10.0.0.0/8", "cidr")
+
+	r := matcher.Explain("www.example.com")
+	if r.Mark != "cidr" || r.Cache || !r.IP.Equal(net.ParseIP("10.2.2.1")) {
+		t.Errorf("first search want cidr by 10.2.2.1, got %v", r)
+	}
+
+	r = matcher.Explain("www.example.com")
+	if r.Mark != "cidr" || !r.Cache {
+		t.Errorf("second search want answered by cache, got %v", r)
+	}
+}
+
+func TestDualStack(t *testing.T) {
+	lookup := func(string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("2001:db8::1")}, nil
+	}
+	for _, matcher := range []*Mapper{NewMapper(lookup), NewOrderedMapper(lookup)} {
+		matcher.Insert("2001:db8::/32", "v6")
+
+		r := matcher.Explain("www.example.com")
+		if r.Mark != "v6" || !r.IP.Equal(net.ParseIP("2001:db8::1")) {
+			t.Errorf("want v6 by 2001:db8::1, got %v", r)
+		}
+	}
+}
+
+func TestPortRule(t *testing.T) {
+	for _, matcher := range []*Mapper{NewMapper(nil), NewOrderedMapper(nil)} {
+		matcher.Insert("udp:443", "block")
+		matcher.Insert("port:22", "direct")
+		matcher.Insert("*.example.com", "proxy")
+		matcher.Insert("tcp:8000-8080", "range")
+		matcher.Insert("port:99999", "invalid")
+
+		for _, v := range []struct {
+			network, addr string
+			want          interface{}
+		}{
+			{"udp", "www.google.com:443", "block"},
+			{"tcp", "www.google.com:443", nil},
+			{"tcp", "www.example.com:22", "direct"},
+			{"tcp", "www.example.com:443", "proxy"},
+			{"tcp4", "10.0.0.1:8080", "range"},
+			{"udp", "10.0.0.1:8080", nil},
+		} {
+			if x := matcher.SearchAddr(v.network, v.addr); x != v.want {
+				t.Errorf("search %s://%s: want %v, got %v", v.network, v.addr, v.want, x)
+			}
+		}
+	}
+
+	matcher := NewMapper(nil)
+	matcher.Insert("network:udp", "udp")
+	if x := matcher.SearchAddr("udp", "10.0.0.1:53"); x != "udp" {
+		t.Errorf("want udp, got %v", x)
+	}
+
+	// the first matched rule wins in the ordered mode
+	matcher = NewOrderedMapper(nil)
+	matcher.Insert("*.example.com", "proxy")
+	matcher.Insert("port:22", "direct")
+	if x := matcher.SearchAddr("tcp", "www.example.com:22"); x != "proxy" {
+		t.Errorf("want proxy, got %v", x)
+	}
+}
+
+func TestOrderedPortRuleLookup(t *testing.T) {
+	lookups := 0
+	matcher := NewOrderedMapper(func(string) ([]net.IP, error) {
+		lookups++
+		return []net.IP{net.IPv4(10, 0, 0, 1)}, nil
+	})
+	matcher.Insert("port:22", "direct")
+	matcher.Insert("10.0.0.0/8", "lan")
+	matcher.Insert("port:8080", "proxy")
+
+	if x := matcher.SearchAddr("tcp", "www.example.com:22"); x != "direct" {
+		t.Errorf("want direct, got %v", x)
+	}
+	if lookups != 0 {
+		t.Errorf("the port rule before the ip rules matched, want no lookup, called %d", lookups)
+	}
+
+	// the ip rule before the port rule wins
+	if x := matcher.SearchAddr("tcp", "www.example.com:8080"); x != "lan" {
+		t.Errorf("want lan, got %v", x)
+	}
+	if lookups != 1 {
+		t.Errorf("want one lookup, called %d", lookups)
+	}
+}
+
+func TestProcessRule(t *testing.T) {
+	matcher := NewMapper(nil)
+	matcher.Insert("*.example.com", "proxy")
+	matcher.Insert("process:curl", "curl")
+	matcher.Insert("UID,1000", "uid")
+
+	called := 0
+	owner := func(name string, uid uint32) Owner {
+		return func() (string, uint32, bool) {
+			called++
+			return name, uid, true
+		}
+	}
+
+	if x := matcher.SearchConn("tcp", "www.example.com:443", owner("curl", 0)); x != "curl" {
+		t.Errorf("want curl, got %v", x)
+	}
+	if x := matcher.SearchConn("tcp", "www.example.com:443", owner("wget", 1000)); x != "uid" {
+		t.Errorf("want uid, got %v", x)
+	}
+	if x := matcher.SearchConn("tcp", "www.example.com:443", owner("wget", 0)); x != "proxy" {
+		t.Errorf("want proxy, got %v", x)
+	}
+	if called != 3 {
+		t.Errorf("owner should be called once per search, called %d", called)
+	}
+	if x := matcher.SearchConn("tcp", "www.example.com:443", nil); x != "proxy" {
+		t.Errorf("want proxy, got %v", x)
+	}
+}
+
+func TestSearchDomain(t *testing.T) {
+	for _, matcher := range []*Mapper{NewMapper(nil), NewOrderedMapper(nil)} {
+		matcher.SetLookup(func(string) ([]net.IP, error) {
+			t.Error("the domain is resolved")
+			return nil, nil
+		})
+		matcher.Insert("network:udp", "block")
+		matcher.Insert("10.0.0.0/8", "direct")
+		matcher.Insert("*.example.com", "proxy")
+
+		if x := matcher.SearchDomain("www.example.com"); x != "proxy" {
+			t.Errorf("want proxy, got %v", x)
+		}
+		if x := matcher.SearchDomain("www.google.com"); x != nil {
+			t.Errorf("want nil, got %v", x)
+		}
+	}
+}
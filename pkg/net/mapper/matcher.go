@@ -0,0 +1,83 @@
+package mapper
+
+import (
+	"regexp"
+	"strings"
+)
+
+//full match the whole domain only, eg: "full:www.example.com"
+type full map[string]interface{}
+
+func (f full) Insert(domain string, mark interface{}) {
+	if len(domain) == 0 {
+		return
+	}
+	f[strings.ToLower(domain)] = mark
+}
+
+func (f full) Search(domain string) (interface{}, bool) {
+	mark, ok := f[strings.ToLower(domain)]
+	return mark, ok
+}
+
+//keyword match the domains that contain the keyword, eg: "keyword:google"
+type keyword struct {
+	rules []keywordRule
+}
+
+type keywordRule struct {
+	keyword string
+	mark    interface{}
+}
+
+func (k *keyword) Insert(key string, mark interface{}) {
+	if len(key) == 0 {
+		return
+	}
+	key = strings.ToLower(key)
+	for i := range k.rules {
+		if k.rules[i].keyword == key {
+			k.rules = append(k.rules[:i], k.rules[i+1:]...)
+			break
+		}
+	}
+	k.rules = append(k.rules, keywordRule{key, mark})
+}
+
+func (k *keyword) Search(domain string) (interface{}, bool) {
+	domain = strings.ToLower(domain)
+	for i := len(k.rules) - 1; i >= 0; i-- { // the later rule override the former
+		if strings.Contains(domain, k.rules[i].keyword) {
+			return k.rules[i].mark, true
+		}
+	}
+	return nil, false
+}
+
+//regexps match the domains that match the regular expression, eg: "regexp:^ads?\d*\."
+type regexps struct {
+	rules []regexpRule
+}
+
+type regexpRule struct {
+	regexp *regexp.Regexp
+	mark   interface{}
+}
+
+func (r *regexps) Insert(expr string, mark interface{}) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	r.rules = append(r.rules, regexpRule{re, mark})
+	return nil
+}
+
+func (r *regexps) Search(domain string) (interface{}, bool) {
+	for i := len(r.rules) - 1; i >= 0; i-- { // the later rule override the former
+		if r.rules[i].regexp.MatchString(domain) {
+			return r.rules[i].mark, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,264 @@
+package mapper
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+//ordered the rules are evaluated in insertion order and the first matched rule wins, like clash,
+//the domain and cidr rules are indexed by maps, so only the keyword, regexp, geoip and
+//the rules like "example.*" are evaluated one by one
+type ordered struct {
+	marks []interface{}
+
+	full   map[string]int // full:www.example.com, www.example.com
+	suffix map[string]int // *.example.com, domain:example.com
+	cidr   map[cidrKey]int
+	cidrV4 []int // the prefix lengths of ipv4 cidr rules
+	cidrV6 []int // the prefix lengths of ipv6 cidr rules
+
+	domains []orderedRule  // keyword, regexp and the domain with wildcard labels
+	geoip   []orderedGeoIP // geoip:cn
+
+	ipMinIndex int // the first index of the ip rules, for skipping the unnecessary lookup
+}
+
+type cidrKey struct {
+	ip   [net.IPv6len]byte
+	bits int
+}
+
+type orderedRule struct {
+	index int
+	match func(string) bool
+}
+
+type orderedGeoIP struct {
+	index   int
+	country string
+}
+
+func newOrdered() *ordered {
+	return &ordered{
+		full:       make(map[string]int),
+		suffix:     make(map[string]int),
+		cidr:       make(map[cidrKey]int),
+		ipMinIndex: -1,
+	}
+}
+
+//next add a new rule, the domains of geosite rule share one index
+func (o *ordered) next(mark interface{}) int {
+	o.marks = append(o.marks, mark)
+	return len(o.marks) - 1
+}
+
+func setFirst(m map[string]int, key string, index int) {
+	if _, ok := m[key]; !ok {
+		m[key] = index
+	}
+}
+
+func (o *ordered) insertFull(domain string, index int) {
+	setFirst(o.full, strings.ToLower(domain), index)
+}
+
+func (o *ordered) insertSuffix(domain string, index int) {
+	setFirst(o.suffix, strings.ToLower(domain), index)
+}
+
+//insertDomain insert the domain like the domain mapper: www.example.com, *.example.com, example.*
+func (o *ordered) insertDomain(domain string, index int) {
+	domain = strings.ToLower(domain)
+	switch {
+	case strings.HasPrefix(domain, "*.") && !strings.Contains(domain[2:], "*"):
+		o.insertSuffix(domain[2:], index)
+	case !strings.Contains(domain, "*"):
+		o.insertFull(domain, index)
+	default:
+		labels := strings.Split(domain, ".")
+		o.domains = append(o.domains, orderedRule{index, func(s string) bool { return matchLabels(labels, s) }})
+	}
+}
+
+//matchLabels "*" match one label, the leading "*" match the domain itself and all subdomains
+func matchLabels(labels []string, domain string) bool {
+	s := strings.Split(strings.ToLower(domain), ".")
+	if len(labels) != 0 && labels[0] == "*" {
+		if len(s) < len(labels)-1 {
+			return false
+		}
+		labels = labels[1:]
+		s = s[len(s)-len(labels):]
+	}
+	if len(s) != len(labels) {
+		return false
+	}
+	for i := range labels {
+		if labels[i] != "*" && labels[i] != s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *ordered) insertKeyword(key string, index int) {
+	key = strings.ToLower(key)
+	o.domains = append(o.domains, orderedRule{index, func(s string) bool { return strings.Contains(strings.ToLower(s), key) }})
+}
+
+func (o *ordered) insertRegexp(expr string, index int) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	o.domains = append(o.domains, orderedRule{index, re.MatchString})
+	return nil
+}
+
+func (o *ordered) insertCIDR(ipNet *net.IPNet, index int) {
+	ones, _ := ipNet.Mask.Size()
+	ip := ipNet.IP.To16()
+	if ipNet.IP.To4() != nil {
+		ones += 96
+		o.cidrV4 = appendBits(o.cidrV4, ones)
+	} else {
+		o.cidrV6 = appendBits(o.cidrV6, ones)
+	}
+
+	k := cidrKey{bits: ones}
+	copy(k.ip[:], ip.Mask(net.CIDRMask(ones, 128)))
+	if _, ok := o.cidr[k]; !ok {
+		o.cidr[k] = index
+	}
+	o.setIPIndex(index)
+}
+
+func appendBits(s []int, bits int) []int {
+	for i := range s {
+		if s[i] == bits {
+			return s
+		}
+	}
+	return append(s, bits)
+}
+
+func (o *ordered) insertGeoIP(country string, index int) {
+	o.geoip = append(o.geoip, orderedGeoIP{index, country})
+	o.setIPIndex(index)
+}
+
+func (o *ordered) setIPIndex(index int) {
+	if o.ipMinIndex == -1 || index < o.ipMinIndex {
+		o.ipMinIndex = index
+	}
+}
+
+//searchDomain return the index of the first matched domain rule, -1 if not found
+func (o *ordered) searchDomain(domain string) int {
+	domain = strings.ToLower(domain)
+	index := -1
+	better := func(i int) {
+		if index == -1 || i < index {
+			index = i
+		}
+	}
+
+	if i, ok := o.full[domain]; ok {
+		better(i)
+	}
+
+	for s := domain; ; {
+		if i, ok := o.suffix[s]; ok {
+			better(i)
+		}
+		d := strings.IndexByte(s, '.')
+		if d == -1 {
+			break
+		}
+		s = s[d+1:]
+	}
+
+	for _, r := range o.domains {
+		if index != -1 && r.index >= index {
+			break
+		}
+		if r.match(domain) {
+			better(r.index)
+		}
+	}
+	return index
+}
+
+//searchIP return the index of the first matched ip rule, -1 if not found
+func (o *ordered) searchIP(ip net.IP, countries func(net.IP) []string) int {
+	index := -1
+	better := func(i int) {
+		if index == -1 || i < index {
+			index = i
+		}
+	}
+
+	ip16 := ip.To16()
+	bits := o.cidrV6
+	if ip.To4() != nil {
+		bits = o.cidrV4
+	}
+	for _, b := range bits {
+		k := cidrKey{bits: b}
+		copy(k.ip[:], ip16.Mask(net.CIDRMask(b, 128)))
+		if i, ok := o.cidr[k]; ok {
+			better(i)
+		}
+	}
+
+	if len(o.geoip) == 0 || countries == nil {
+		return index
+	}
+	cs := countries(ip)
+	for _, r := range o.geoip {
+		if index != -1 && r.index >= index {
+			break
+		}
+		for _, c := range cs {
+			if c == r.country {
+				better(r.index)
+				break
+			}
+		}
+	}
+	return index
+}
+
+//search the domain rules first, then lookup the ip if there is any ip rule before the matched domain rule,
+//the ip rules are matched with all resolved ips, return the index of the matched rule and the ip used for the ip rules
+func (o *ordered) search(str string, lookup func(string) ([]net.IP, error), countries func(net.IP) []string) (int, net.IP) {
+	if ip := net.ParseIP(str); ip != nil {
+		return o.searchIP(ip, countries), ip
+	}
+
+	index := o.searchDomain(str)
+	if o.ipMinIndex == -1 || (index != -1 && index < o.ipMinIndex) || lookup == nil {
+		return index, nil
+	}
+
+	ips, err := lookup(str)
+	if err != nil || len(ips) == 0 {
+		return index, nil
+	}
+	ip := ips[0]
+	for _, x := range ips {
+		if i := o.searchIP(x, countries); i != -1 && (index == -1 || i < index) {
+			index, ip = i, x
+		}
+	}
+	return index, ip
+}
+
+func (o *ordered) mark(index int) interface{} {
+	if index < 0 || index >= len(o.marks) {
+		return nil
+	}
+	return o.marks[index]
+}
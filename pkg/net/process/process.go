@@ -0,0 +1,16 @@
+package process
+
+import (
+	"errors"
+)
+
+//ErrNotSupported find process is not supported on this platform
+var ErrNotSupported = errors.New("find process is not supported")
+
+//Process the owner of a socket
+type Process struct {
+	PID  int
+	UID  uint32
+	Name string
+	Path string
+}
@@ -0,0 +1,162 @@
+package process
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//FindProcess find the process that owns the local socket, network: tcp or udp, addr: the local address of the socket
+func FindProcess(network string, addr net.Addr) (*Process, error) {
+	ip, port, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	switch {
+	case strings.HasPrefix(network, "tcp"):
+		files = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+	case strings.HasPrefix(network, "udp"):
+		files = []string{"/proc/net/udp", "/proc/net/udp6"}
+	default:
+		return nil, fmt.Errorf("unknown network: %s", network)
+	}
+
+	for _, f := range files {
+		uid, inode, err := findSocket(f, ip, port)
+		if err != nil {
+			continue
+		}
+
+		p := &Process{UID: uid, PID: -1}
+		p.PID, err = findPID(inode)
+		if err != nil {
+			return p, err
+		}
+		p.Path, _ = os.Readlink(fmt.Sprintf("/proc/%d/exe", p.PID))
+		comm, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", p.PID))
+		if err == nil {
+			p.Name = strings.TrimSpace(string(comm))
+		}
+		if p.Path != "" {
+			p.Name = filepath.Base(p.Path)
+		}
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("socket of %s %v is not found", network, addr)
+}
+
+func splitAddr(addr net.Addr) (net.IP, int, error) {
+	switch x := addr.(type) {
+	case *net.TCPAddr:
+		return x.IP, x.Port, nil
+	case *net.UDPAddr:
+		return x.IP, x.Port, nil
+	}
+
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("split host port failed: %v", err)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse port failed: %v", err)
+	}
+	return net.ParseIP(host), p, nil
+}
+
+//findSocket find the uid and inode of the socket from /proc/net/tcp, the format:
+//  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+//   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 123456
+func findSocket(file string, ip net.IP, port int) (uint32, string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	s.Scan() // skip the header
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 10 || fields[3] == "0A" { // skip the listening tcp sockets
+			continue
+		}
+
+		i := strings.IndexByte(fields[1], ':')
+		if i == -1 {
+			continue
+		}
+		p, err := strconv.ParseUint(fields[1][i+1:], 16, 16)
+		if err != nil || int(p) != port {
+			continue
+		}
+		lip, err := parseIP(fields[1][:i])
+		if err != nil || !(lip.Equal(ip) || (lip.IsUnspecified() && ip.IsLoopback())) {
+			continue
+		}
+
+		if fields[9] == "0" {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		return uint32(uid), fields[9], nil
+	}
+	return 0, "", fmt.Errorf("not found")
+}
+
+//parseIP the ip is stored as 32-bit words in host byte order
+func parseIP(s string) (net.IP, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != net.IPv4len && len(b) != net.IPv6len {
+		return nil, fmt.Errorf("invalid ip: %s", s)
+	}
+	for i := 0; i < len(b); i += 4 {
+		binary.BigEndian.PutUint32(b[i:], binary.LittleEndian.Uint32(b[i:]))
+	}
+	return net.IP(b), nil
+}
+
+//findPID find the process that has the socket inode in /proc/<pid>/fd
+func findPID(inode string) (int, error) {
+	target := "socket:[" + inode + "]"
+
+	ds, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return -1, err
+	}
+
+	for _, d := range ds {
+		pid, err := strconv.Atoi(d.Name())
+		if err != nil || !d.IsDir() {
+			continue
+		}
+
+		fd := filepath.Join("/proc", d.Name(), "fd")
+		fs, err := ioutil.ReadDir(fd)
+		if err != nil {
+			continue
+		}
+		for _, f := range fs {
+			if l, err := os.Readlink(filepath.Join(fd, f.Name())); err == nil && l == target {
+				return pid, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("process of socket %s is not found", inode)
+}
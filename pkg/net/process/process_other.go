@@ -0,0 +1,10 @@
+// +build !linux
+
+package process
+
+import "net"
+
+//FindProcess find the process that owns the local socket, network: tcp or udp, addr: the local address of the socket
+func FindProcess(network string, addr net.Addr) (*Process, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,35 @@
+package process
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestFindProcess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("only support linux")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	c, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	p, err := FindProcess("tcp", c.LocalAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(p)
+	if p.PID != os.Getpid() || p.UID != uint32(os.Getuid()) {
+		t.Errorf("want pid %d uid %d, got %v", os.Getpid(), os.Getuid(), p)
+	}
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+//Client the grpc transport of v2ray/xray, the data is sent by the bidirectional stream /<service name>/Tun,
+//the message is Hunk{bytes data = 1}, the same encoding as google.protobuf.BytesValue
+type Client struct {
+	method string
+	conn   *grpc.ClientConn
+}
+
+var tunDesc = &grpc.StreamDesc{StreamName: "Tun", ServerStreams: true, ClientStreams: true}
+
+//NewClient conn: dial the server, host: the authority of the requests,
+//tlsConfig: the tls of the grpc, nil to send the requests without tls
+func NewClient(conn func() (net.Conn, error), host, serviceName string, tlsConfig *tls.Config) (*Client, error) {
+	creds := grpc.WithInsecure()
+	if tlsConfig != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	c, err := grpc.Dial(host,
+		creds,
+		grpc.WithAuthority(host),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return conn() }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client failed: %v", err)
+	}
+
+	if serviceName == "" {
+		serviceName = "GunService"
+	}
+	return &Client{method: "/" + strings.TrimPrefix(serviceName, "/") + "/Tun", conn: c}, nil
+}
+
+func (c *Client) NewConn() (net.Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := c.conn.NewStream(ctx, tunDesc, c.method)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("create grpc stream failed: %v", err)
+	}
+	return &streamConn{stream: s, cancel: cancel}, nil
+}
+
+var _ net.Conn = (*streamConn)(nil)
+
+type streamConn struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	buf    []byte
+
+	rlock sync.Mutex
+	wlock sync.Mutex
+	once  sync.Once
+
+	// the stream can't be interrupted by one side, so the expired deadline cancels the whole stream
+	dlock   sync.Mutex
+	rtimer  *time.Timer
+	wtimer  *time.Timer
+	expired int32
+}
+
+func (s *streamConn) Read(b []byte) (int, error) {
+	s.rlock.Lock()
+	defer s.rlock.Unlock()
+
+	if len(s.buf) == 0 {
+		h := &wrapperspb.BytesValue{}
+		if err := s.stream.RecvMsg(h); err != nil {
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			if atomic.LoadInt32(&s.expired) == 1 {
+				return 0, os.ErrDeadlineExceeded
+			}
+			return 0, fmt.Errorf("grpc receive failed: %w", err)
+		}
+		s.buf = h.Value
+	}
+	n := copy(b, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *streamConn) Write(b []byte) (int, error) {
+	s.wlock.Lock()
+	defer s.wlock.Unlock()
+
+	if err := s.stream.SendMsg(&wrapperspb.BytesValue{Value: b}); err != nil {
+		if atomic.LoadInt32(&s.expired) == 1 {
+			return 0, os.ErrDeadlineExceeded
+		}
+		return 0, fmt.Errorf("grpc send failed: %w", err)
+	}
+	return len(b), nil
+}
+
+func (s *streamConn) Close() error {
+	s.once.Do(func() {
+		_ = s.stream.CloseSend()
+		s.cancel()
+	})
+	return nil
+}
+
+type grpcAddr struct{}
+
+func (grpcAddr) Network() string { return "grpc" }
+func (grpcAddr) String() string  { return "grpc" }
+
+func (s *streamConn) LocalAddr() net.Addr  { return grpcAddr{} }
+func (s *streamConn) RemoteAddr() net.Addr { return grpcAddr{} }
+
+func (s *streamConn) SetDeadline(t time.Time) error {
+	s.dlock.Lock()
+	defer s.dlock.Unlock()
+	s.rtimer, s.wtimer = s.timer(s.rtimer, t), s.timer(s.wtimer, t)
+	return nil
+}
+
+func (s *streamConn) SetReadDeadline(t time.Time) error {
+	s.dlock.Lock()
+	defer s.dlock.Unlock()
+	s.rtimer = s.timer(s.rtimer, t)
+	return nil
+}
+
+func (s *streamConn) SetWriteDeadline(t time.Time) error {
+	s.dlock.Lock()
+	defer s.dlock.Unlock()
+	s.wtimer = s.timer(s.wtimer, t)
+	return nil
+}
+
+//timer stop the old timer, and cancel the stream at t, the zero t means no deadline
+func (s *streamConn) timer(old *time.Timer, t time.Time) *time.Timer {
+	if old != nil {
+		old.Stop()
+	}
+	if t.IsZero() {
+		return nil
+	}
+	return time.AfterFunc(time.Until(t), func() {
+		atomic.StoreInt32(&s.expired, 1)
+		s.cancel()
+	})
+}
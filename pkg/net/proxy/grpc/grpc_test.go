@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newEchoClient(t *testing.T) *Client {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		for {
+			h := &wrapperspb.BytesValue{}
+			if err := stream.RecvMsg(h); err != nil {
+				return nil
+			}
+			if err := stream.SendMsg(h); err != nil {
+				return nil
+			}
+		}
+	}))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := NewClient(func() (net.Conn, error) { return net.Dial("tcp", lis.Addr().String()) }, "example.com", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestReadDeadline(t *testing.T) {
+	conn, err := newEchoClient(t).NewConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// the cleared deadline doesn't cancel the stream
+	_ = conn.SetDeadline(time.Now().Add(50 * time.Millisecond))
+	_ = conn.SetDeadline(time.Time{})
+	time.Sleep(100 * time.Millisecond)
+	proxytest.Echo(t, conn)
+
+	_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	done := make(chan error)
+	go func() {
+		_, err := conn.Read(make([]byte, 5))
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("want deadline exceeded, got %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("the read deadline doesn't work")
+	}
+}
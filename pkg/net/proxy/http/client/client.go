@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+)
+
+// client http proxy client, the connections are created by the CONNECT method
+// https://tools.ietf.org/html/rfc7231#section-4.3.6
+type client struct {
+	server    string
+	user      string
+	password  string
+	tlsConfig *tls.Config
+
+	*utils.ClientUtil
+}
+
+//NewHTTPClient create a http proxy client, the proxy is connected by tls if tlsEnable,
+//the server is used as the tls server name if the sni is empty
+func NewHTTPClient(host, port, user, password string, tlsEnable bool, sni string, insecureSkipVerify bool) proxy.Proxy {
+	x := &client{
+		server:     host,
+		user:       user,
+		password:   password,
+		ClientUtil: utils.NewClientUtil(host, port),
+	}
+	if sni == "" {
+		sni = host
+	}
+	if tlsEnable {
+		x.tlsConfig = &tls.Config{
+			ServerName:         sni,
+			InsecureSkipVerify: insecureSkipVerify,
+		}
+	}
+	return x
+}
+
+func (c *client) Conn(host string) (net.Conn, error) {
+	conn, err := c.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("dial to %s failed: %v", c.server, err)
+	}
+	if x, ok := conn.(*net.TCPConn); ok {
+		_ = x.SetKeepAlive(true)
+	}
+
+	if c.tlsConfig != nil {
+		tlsConn := tls.Client(conn, c.tlsConfig)
+		if err = tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("tls handshake failed: %v", err)
+		}
+		conn = tlsConn
+	}
+
+	conn, err = c.connect(conn, host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("http connect to %s failed: %v", host, err)
+	}
+	return conn, nil
+}
+
+func (c *client) connect(conn net.Conn, host string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: host},
+		Host:   host,
+		Header: make(http.Header),
+	}
+	if c.user != "" || c.password != "" {
+		req.Header.Set("Proxy-Authorization",
+			"Basic "+base64.StdEncoding.EncodeToString([]byte(c.user+":"+c.password)))
+	}
+	if err := req.Write(conn); err != nil {
+		return conn, fmt.Errorf("write request failed: %v", err)
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return conn, fmt.Errorf("read response failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return conn, fmt.Errorf("proxy response: %s", resp.Status)
+	}
+
+	if r.Buffered() == 0 {
+		return conn, nil
+	}
+	// the data of the target is read with the response
+	return &bufConn{Conn: conn, r: r}, nil
+}
+
+func (c *client) PacketConn(string) (net.PacketConn, error) {
+	return nil, errors.New("http proxy not support udp")
+}
+
+type bufConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (b *bufConn) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
+)
+
+func TestImplement(t *testing.T) {
+	// make sure implement
+	var _ proxy.Proxy = new(client)
+}
+
+//testServer a http proxy sending the banner after the response and echoing the data, the connections are tls if config isn't nil
+func testServer(t *testing.T, config *tls.Config) (string, string) {
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	return proxytest.Serve(t, config, func(c net.Conn) {
+		r := bufio.NewReader(c)
+		req, err := http.ReadRequest(r)
+		if err != nil {
+			return
+		}
+		if req.Method != http.MethodConnect || req.Host != "example.com:443" {
+			_, _ = c.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			return
+		}
+		if req.Header.Get("Proxy-Authorization") != auth {
+			_, _ = c.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+		_, _ = c.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\nbanner"))
+		_, _ = io.Copy(c, r)
+	})
+}
+
+func echo(t *testing.T, p proxy.Proxy) {
+	conn, err := p.Conn("example.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	b := make([]byte, 6)
+	if _, err = io.ReadFull(conn, b); err != nil || string(b) != "banner" {
+		t.Errorf("want banner, got %s %v", b, err)
+	}
+	proxytest.Echo(t, conn)
+}
+
+func TestConn(t *testing.T) {
+	host, port := testServer(t, nil)
+	echo(t, NewHTTPClient(host, port, "user", "pass", false, "", false))
+
+	if _, err := NewHTTPClient(host, port, "user", "wrong", false, "", false).Conn("example.com:443"); err == nil {
+		t.Error("want the error of the wrong password")
+	}
+
+	host, port = testServer(t, proxytest.TLSConfig(t, "proxy.test"))
+	echo(t, NewHTTPClient(host, port, "user", "pass", true, "proxy.test", true))
+
+	// the certificate isn't trusted
+	if _, err := NewHTTPClient(host, port, "user", "pass", true, "proxy.test", false).Conn("example.com:443"); err == nil {
+		t.Error("want the certificate verify error")
+	}
+}
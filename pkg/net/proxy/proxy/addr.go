@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+//DomainAddr the unresolved address of domain:port, the outbound resolves it,
+//so the domain is resolved by the dns of the outbound, not the local system
+type DomainAddr struct {
+	Net  string
+	Addr string
+}
+
+func (d *DomainAddr) Network() string { return d.Net }
+func (d *DomainAddr) String() string  { return d.Addr }
+
+var _ net.Addr = (*DomainAddr)(nil)
+
+//NewResolvePacketConn the addresses except *net.UDPAddr written to pc are resolved by r, nil for the default resolver
+func NewResolvePacketConn(pc net.PacketConn, r *net.Resolver) net.PacketConn {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	return &resolvePacketConn{PacketConn: pc, resolver: r}
+}
+
+type resolvePacketConn struct {
+	net.PacketConn
+	resolver *net.Resolver
+}
+
+func (r *resolvePacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	if _, ok := addr.(*net.UDPAddr); ok {
+		return r.PacketConn.WriteTo(b, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return 0, fmt.Errorf("split host port of %s failed: %v", addr, err)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port of %s: %v", addr, err)
+	}
+	ips, err := r.resolver.LookupIPAddr(context.Background(), host)
+	if err != nil {
+		return 0, fmt.Errorf("resolve %s failed: %v", host, err)
+	}
+	if len(ips) == 0 {
+		return 0, fmt.Errorf("no address of %s", host)
+	}
+	return r.PacketConn.WriteTo(b, &net.UDPAddr{IP: ips[0].IP, Port: int(p), Zone: ips[0].Zone})
+}
@@ -0,0 +1,34 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+)
+
+func TestDomainAddr(t *testing.T) {
+	l, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	_, port, _ := net.SplitHostPort(l.LocalAddr().String())
+
+	pc, err := (&DefaultProxy{}).PacketConn("localhost:" + port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	if _, err = pc.WriteTo([]byte("hello"), &DomainAddr{Net: "udp", Addr: net.JoinHostPort("127.0.0.1", port)}); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 10)
+	n, _, err := l.ReadFrom(b)
+	if err != nil || string(b[:n]) != "hello" {
+		t.Errorf("want hello, got %s %v", b[:n], err)
+	}
+
+	if _, err = pc.WriteTo([]byte("hello"), &DomainAddr{Net: "udp", Addr: "localhost"}); err == nil {
+		t.Error("want the error of the address without port")
+	}
+}
@@ -0,0 +1,52 @@
+package proxy
+
+import "net"
+
+//Metadata the information of the inbound connection
+type Metadata struct {
+	// Source the source address of the inbound connection
+	Source net.Addr
+	// Inbound the name of the inbound listener, eg: socks5, http, redir
+	Inbound string
+}
+
+//MetadataProxy the proxy that can use the metadata of the inbound connection for routing
+type MetadataProxy interface {
+	Proxy
+	ConnWithMetadata(string, *Metadata) (net.Conn, error)
+	PacketConnWithMetadata(string, *Metadata) (net.PacketConn, error)
+}
+
+//ConnWithMetadata call p.ConnWithMetadata if p is a MetadataProxy, otherwise p.Conn
+func ConnWithMetadata(p Proxy, host string, m *Metadata) (net.Conn, error) {
+	if x, ok := p.(MetadataProxy); ok && m != nil {
+		return x.ConnWithMetadata(host, m)
+	}
+	return p.Conn(host)
+}
+
+//PacketConnWithMetadata call p.PacketConnWithMetadata if p is a MetadataProxy, otherwise p.PacketConn
+func PacketConnWithMetadata(p Proxy, host string, m *Metadata) (net.PacketConn, error) {
+	if x, ok := p.(MetadataProxy); ok && m != nil {
+		return x.PacketConnWithMetadata(host, m)
+	}
+	return p.PacketConn(host)
+}
+
+//WithMetadata bind the metadata to p, so the handlers of the servers needn't know the metadata
+func WithMetadata(p Proxy, m *Metadata) Proxy {
+	return &metadataProxy{p, m}
+}
+
+type metadataProxy struct {
+	p Proxy
+	m *Metadata
+}
+
+func (m *metadataProxy) Conn(host string) (net.Conn, error) {
+	return ConnWithMetadata(m.p, host, m.m)
+}
+
+func (m *metadataProxy) PacketConn(host string) (net.PacketConn, error) {
+	return PacketConnWithMetadata(m.p, host, m.m)
+}
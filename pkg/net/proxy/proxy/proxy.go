@@ -16,5 +16,9 @@ func (d *DefaultProxy) Conn(s string) (net.Conn, error) {
 	return net.DialTimeout("tcp", s, 15*time.Second)
 }
 func (d *DefaultProxy) PacketConn(string) (net.PacketConn, error) {
-	return net.ListenPacket("udp", "")
+	pc, err := net.ListenPacket("udp", "")
+	if err != nil {
+		return nil, err
+	}
+	return NewResolvePacketConn(pc, nil), nil
 }
@@ -92,14 +92,22 @@ func (t *TCPServer) getProxy() Proxy {
 }
 
 func (t *TCPServer) Conn(host string) (net.Conn, error) {
+	return t.ConnWithMetadata(host, nil)
+}
+
+func (t *TCPServer) PacketConn(host string) (net.PacketConn, error) {
+	return t.PacketConnWithMetadata(host, nil)
+}
+
+func (t *TCPServer) ConnWithMetadata(host string, m *Metadata) (net.Conn, error) {
 	if t.listener.Addr().String() == host {
 		return nil, fmt.Errorf("access host same as listener: %v", t.listener.Addr())
 	}
-	return t.getProxy().Conn(host)
+	return ConnWithMetadata(t.getProxy(), host, m)
 }
 
-func (t *TCPServer) PacketConn(host string) (net.PacketConn, error) {
-	return t.getProxy().PacketConn(host)
+func (t *TCPServer) PacketConnWithMetadata(host string, m *Metadata) (net.PacketConn, error) {
+	return PacketConnWithMetadata(t.getProxy(), host, m)
 }
 
 func (t *TCPServer) GetListenHost() string {
@@ -159,7 +167,7 @@ func (t *TCPServer) process() error {
 
 		go func() {
 			defer c.Close()
-			t.handle(c, t)
+			t.handle(c, WithMetadata(t, &Metadata{Source: c.RemoteAddr()}))
 		}()
 	}
 }
@@ -121,14 +121,22 @@ func (u *UDPServer) run() (err error) {
 }
 
 func (t *UDPServer) Conn(host string) (net.Conn, error) {
-	return t.getProxy().Conn(host)
+	return t.ConnWithMetadata(host, nil)
 }
 
 func (t *UDPServer) PacketConn(host string) (net.PacketConn, error) {
+	return t.PacketConnWithMetadata(host, nil)
+}
+
+func (t *UDPServer) ConnWithMetadata(host string, m *Metadata) (net.Conn, error) {
+	return ConnWithMetadata(t.getProxy(), host, m)
+}
+
+func (t *UDPServer) PacketConnWithMetadata(host string, m *Metadata) (net.PacketConn, error) {
 	if t.listener.LocalAddr().String() == host {
 		return nil, fmt.Errorf("access host same as listener: %v", t.listener.LocalAddr())
 	}
-	return t.getProxy().PacketConn(host)
+	return PacketConnWithMetadata(t.getProxy(), host, m)
 }
 
 func (u *UDPServer) process() error {
@@ -171,7 +179,7 @@ func (u *UDPServer) defaultListenFunc(l net.PacketConn) error {
 
 		tempDelay = 0
 		go func() {
-			data, err := u.handle(b[:n], u)
+			data, err := u.handle(b[:n], WithMetadata(u, &Metadata{Source: remoteAddr}))
 			if err != nil {
 				log.Printf("udp handle failed: %v", err)
 				return
@@ -0,0 +1,73 @@
+//Package proxytest the servers and certificates for the tests of the proxies
+package proxytest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+//TLSConfig the server config with a self-signed certificate of the names, the certificate is valid for an hour
+func TLSConfig(t testing.TB, names ...string) *tls.Config {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &k.PublicKey, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: k}}}
+}
+
+//Serve listen on a random port of 127.0.0.1, every connection is handled by handle and closed after it,
+//the connections are tls if config isn't nil, the listener is closed when the test finishes
+func Serve(t testing.TB, config *tls.Config, handle func(net.Conn)) (host, port string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config != nil {
+		lis = tls.NewListener(lis, config)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			c, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				handle(c)
+			}()
+		}
+	}()
+	host, port, _ = net.SplitHostPort(lis.Addr().String())
+	return host, port
+}
+
+//Echo write hello to conn and want the same data back
+func Echo(t testing.TB, conn net.Conn) {
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 5)
+	if _, err := io.ReadFull(conn, b); err != nil || string(b) != "hello" {
+		t.Errorf("want hello, got %s %v", b, err)
+	}
+}
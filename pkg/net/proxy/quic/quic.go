@@ -20,31 +20,6 @@ type Client struct {
 }
 
 func NewClient(network, address string, port int, certPath []string, insecureSkipVerify bool) (*Client, error) {
-	c := &Client{}
-	var err error
-
-	switch network {
-	case "ip":
-		var ip net.IP
-		ip = net.ParseIP(address)
-		if ip == nil {
-			addrs, err := net.LookupAddr(address)
-			if err != nil || len(addrs) == 0 {
-				return nil, fmt.Errorf("look addr failed: %v", err)
-			}
-			ip = net.ParseIP(addrs[0])
-			if ip == nil {
-				return nil, fmt.Errorf("can't get ip")
-			}
-		}
-		c.addr = &net.UDPAddr{IP: ip, Port: port}
-	default:
-		c.addr, err = net.ResolveUDPAddr("udp", address)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	root, err := x509.SystemCertPool()
 	if err != nil {
 		return nil, fmt.Errorf("get system cert pool failed: %v", err)
@@ -55,7 +30,7 @@ func NewClient(network, address string, port int, certPath []string, insecureSki
 		log.Printf("split host and port failed: %v", err)
 		ns = address
 	}
-	c.tlsConfig = &tls.Config{
+	tlsConfig := &tls.Config{
 		RootCAs:                root,
 		ServerName:             ns,
 		SessionTicketsDisabled: true,
@@ -74,7 +49,7 @@ func NewClient(network, address string, port int, certPath []string, insecureSki
 			continue
 		}
 
-		ok := c.tlsConfig.RootCAs.AppendCertsFromPEM(cert)
+		ok := tlsConfig.RootCAs.AppendCertsFromPEM(cert)
 		if !ok {
 			log.Printf("add cert from pem failed.")
 		}
@@ -99,6 +74,36 @@ func NewClient(network, address string, port int, certPath []string, insecureSki
 		// tlsConfig.RootCAs.AddCert(certA)
 	}
 
+	return NewClientWithTLS(network, address, port, tlsConfig)
+}
+
+//NewClientWithTLS create the client with the tls config, the server name, alpn and verification are all taken from tlsConfig
+func NewClientWithTLS(network, address string, port int, tlsConfig *tls.Config) (*Client, error) {
+	c := &Client{tlsConfig: tlsConfig}
+	var err error
+
+	switch network {
+	case "ip":
+		var ip net.IP
+		ip = net.ParseIP(address)
+		if ip == nil {
+			addrs, err := net.LookupAddr(address)
+			if err != nil || len(addrs) == 0 {
+				return nil, fmt.Errorf("look addr failed: %v", err)
+			}
+			ip = net.ParseIP(addrs[0])
+			if ip == nil {
+				return nil, fmt.Errorf("can't get ip")
+			}
+		}
+		c.addr = &net.UDPAddr{IP: ip, Port: port}
+	default:
+		c.addr, err = net.ResolveUDPAddr("udp", address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	c.quicConfig = &quic.Config{
 		KeepAlive:          true,
 		ConnectionIDLength: 12,
@@ -5,8 +5,10 @@ package server
 import (
 	"net"
 
+	"github.com/Asutorufa/yuhaiin/pkg/net/fakeip"
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/redir/nfutil"
+	"github.com/Asutorufa/yuhaiin/pkg/net/sniff"
 	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
 )
 
@@ -18,7 +20,17 @@ func handle(req net.Conn, f proxy.Proxy) error {
 		return err
 	}
 
-	rsp, err := f.Conn(target.String())
+	// the domain rules can't match the original destination ip,
+	// so reverse map the fake ip or sniff the domain from the request
+	var conn net.Conn = req
+	addr, ok := fakeip.Addr(target.String())
+	if !ok && !sniff.Skip(addr) {
+		var domain string
+		domain, conn = sniff.Sniff(req)
+		addr = sniff.Addr(addr, domain)
+	}
+
+	rsp, err := f.Conn(addr)
 	if err != nil {
 		return err
 	}
@@ -28,6 +40,6 @@ func handle(req net.Conn, f proxy.Proxy) error {
 	}
 
 	defer rsp.Close()
-	utils.Forward(req, rsp)
+	utils.Forward(conn, rsp)
 	return nil
 }
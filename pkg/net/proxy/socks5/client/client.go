@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"strconv"
@@ -45,11 +46,13 @@ func (s *client) Conn(host string) (net.Conn, error) {
 
 	err = s.handshake1(conn)
 	if err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("first hand failed: %v", err)
 	}
 
 	err = s.handshake2(conn, host)
 	if err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("second hand failed: %v", err)
 	}
 
@@ -57,13 +60,17 @@ func (s *client) Conn(host string) (net.Conn, error) {
 }
 
 func (s *client) handshake1(conn net.Conn) error {
-	sendData := bytes.NewBuffer([]byte{0x05, 0x01, 0x00})
-	_, err := conn.Write(sendData.Bytes())
+	methods := []byte{0x05, 0x01, 0x00}
+	if s.username != "" || s.password != "" {
+		// no authentication required or username/password
+		methods = []byte{0x05, 0x02, 0x00, 0x02}
+	}
+	_, err := conn.Write(methods)
 	if err != nil {
 		return fmt.Errorf("firstVerify:sendData -> %v", err)
 	}
-	getData := make([]byte, 3)
-	_, err = conn.Read(getData[:])
+	getData := make([]byte, 2)
+	_, err = io.ReadFull(conn, getData)
 	if err != nil {
 		return fmt.Errorf("firstVerify:Read -> %v", err)
 	}
@@ -72,18 +79,24 @@ func (s *client) handshake1(conn net.Conn) error {
 	}
 
 	//username and password
+	//https://tools.ietf.org/html/rfc1929
 	if getData[1] == 0x02 {
-		sendData.Write([]byte{0x01, byte(len(s.username))})
+		if len(s.username) > 255 || len(s.password) > 255 {
+			return fmt.Errorf("firstVerify -> %v", errors.New("username or password is too long"))
+		}
+		sendData := bytes.NewBuffer([]byte{0x01, byte(len(s.username))})
 		sendData.WriteString(s.username)
 		sendData.WriteByte(byte(len(s.password)))
 		sendData.WriteString(s.password)
-		_, _ = conn.Write(sendData.Bytes())
+		if _, err = conn.Write(sendData.Bytes()); err != nil {
+			return fmt.Errorf("firstVerify:sendUserPass -> %v", err)
+		}
 
-		_, err = conn.Read(getData[:])
+		_, err = io.ReadFull(conn, getData)
 		if err != nil {
 			return fmt.Errorf("firstVerify:Read2 -> %v", err)
 		}
-		if getData[1] == 0x01 {
+		if getData[1] != 0x00 {
 			return fmt.Errorf("firstVerify -> %v", errors.New("username or password not correct,socks5 handshake failed"))
 		}
 	}
@@ -102,14 +115,27 @@ func (s *client) handshake2(conn net.Conn, address string) error {
 		return err
 	}
 
-	getData := make([]byte, 1024)
-	if _, err = conn.Read(getData[:]); err != nil {
+	// VER REP RSV ATYP BND.ADDR BND.PORT, read exactly the reply, the data of the target may follow it
+	getData := make([]byte, 4+1+255+2)
+	if _, err = io.ReadFull(conn, getData[:5]); err != nil {
 		return err
 	}
 	if getData[0] != 0x05 || getData[1] != 0x00 {
 		return errors.New("socks5 second handshake failed")
 	}
-	return nil
+	var size int
+	switch getData[3] {
+	case 0x01:
+		size = net.IPv4len - 1 + 2
+	case 0x04:
+		size = net.IPv6len - 1 + 2
+	case 0x03:
+		size = int(getData[4]) + 2
+	default:
+		return fmt.Errorf("unknown address type: %d", getData[3])
+	}
+	_, err = io.ReadFull(conn, getData[5:5+size])
+	return err
 }
 
 func (s *client) PacketConn(host string) (net.PacketConn, error) {
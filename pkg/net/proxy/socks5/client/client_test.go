@@ -1,12 +1,78 @@
 package socks5client
 
 import (
+	"bytes"
+	"io"
+	"net"
 	"testing"
 
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
 )
 
 func TestImplement(t *testing.T) {
 	// make sure implement
 	var _ proxy.Proxy = new(client)
 }
+
+//testServer a socks5 server requiring the username and password, the banner is sent with the reply
+func testServer(t *testing.T) (string, string) {
+	return proxytest.Serve(t, nil, func(c net.Conn) {
+		b := make([]byte, 512)
+		if _, err := io.ReadFull(c, b[:2]); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(c, b[:b[1]]); err != nil || bytes.IndexByte(b[:b[1]], 0x02) == -1 {
+			_, _ = c.Write([]byte{0x05, 0xff})
+			return
+		}
+		_, _ = c.Write([]byte{0x05, 0x02})
+
+		// VER ULEN UNAME PLEN PASSWD
+		if _, err := io.ReadFull(c, b[:2]); err != nil {
+			return
+		}
+		user := make([]byte, b[1])
+		_, _ = io.ReadFull(c, user)
+		_, _ = io.ReadFull(c, b[:1])
+		pass := make([]byte, b[0])
+		_, _ = io.ReadFull(c, pass)
+		if string(user) != "user" || string(pass) != "pass" {
+			_, _ = c.Write([]byte{0x01, 0x01})
+			return
+		}
+		_, _ = c.Write([]byte{0x01, 0x00})
+
+		target, _ := ParseAddr("example.com:443")
+		req := append([]byte{0x05, 0x01, 0x00}, target...)
+		if _, err := io.ReadFull(c, b[:len(req)]); err != nil || !bytes.Equal(b[:len(req)], req) {
+			return
+		}
+		bind, _ := ParseAddr("127.0.0.1:1080")
+		_, _ = c.Write(append(append([]byte{0x05, 0x00, 0x00}, bind...), "banner"...))
+		_, _ = io.Copy(c, c)
+	})
+}
+
+func TestConn(t *testing.T) {
+	host, port := testServer(t)
+
+	conn, err := NewSocks5Client(host, port, "user", "pass").Conn("example.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	b := make([]byte, 6)
+	if _, err = io.ReadFull(conn, b); err != nil || string(b) != "banner" {
+		t.Errorf("want banner, got %s %v", b, err)
+	}
+	proxytest.Echo(t, conn)
+
+	if _, err = NewSocks5Client(host, port, "user", "wrong").Conn("example.com:443"); err == nil {
+		t.Error("want the error of the wrong password")
+	}
+	if _, err = NewSocks5Client(host, port, "", "").Conn("example.com:443"); err == nil {
+		t.Error("want the error without the password")
+	}
+}
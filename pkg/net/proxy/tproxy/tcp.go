@@ -5,7 +5,9 @@ import (
 	"net"
 	"syscall"
 
+	"github.com/Asutorufa/yuhaiin/pkg/net/fakeip"
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/sniff"
 	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
 )
 
@@ -32,13 +34,21 @@ func controlTCP(network, address string, c syscall.RawConn) error {
 }
 
 func handleTCP(c net.Conn, p proxy.Proxy) {
-	r, err := p.Conn(c.LocalAddr().String())
+	var conn net.Conn = c
+	addr, ok := fakeip.Addr(c.LocalAddr().String())
+	if !ok && !sniff.Skip(addr) {
+		var domain string
+		domain, conn = sniff.Sniff(c)
+		addr = sniff.Addr(addr, domain)
+	}
+
+	r, err := p.Conn(addr)
 	if err != nil {
 		log.Printf("get conn failed: %v", err)
 		return
 	}
 
-	utils.Forward(c, r)
+	utils.Forward(conn, r)
 }
 
 func newTCPServer(h string) (proxy.Server, error) {
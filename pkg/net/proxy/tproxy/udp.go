@@ -13,6 +13,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/Asutorufa/yuhaiin/pkg/net/fakeip"
 	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
 )
 
@@ -136,13 +137,19 @@ func handleSingleUDPReq(oob, b []byte, addr *net.UDPAddr, p proxy.Proxy) error {
 		return fmt.Errorf("unable to obtain original destination: %s", err)
 	}
 
-	conn, err := p.PacketConn(originalDst.IP.String())
+	host, target := originalDst.String(), net.Addr(originalDst)
+	if addr, ok := fakeip.Addr(host); ok {
+		// the domain is resolved by the routed outbound, the local system may answer the fake ip again
+		host, target = addr, &proxy.DomainAddr{Net: "udp", Addr: addr}
+	}
+
+	conn, err := p.PacketConn(host)
 	if err != nil {
 		return fmt.Errorf("get packet conn failed: %w", err)
 	}
 	defer conn.Close()
 
-	_, err = conn.WriteTo(b, originalDst)
+	_, err = conn.WriteTo(b, target)
 	if err != nil {
 		return fmt.Errorf("write data to remote server failed: %w", err)
 	}
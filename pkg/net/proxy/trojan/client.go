@@ -0,0 +1,197 @@
+package trojan
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	ss5client "github.com/Asutorufa/yuhaiin/pkg/net/proxy/socks5/client"
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+)
+
+// https://trojan-gfw.github.io/trojan/protocol
+const (
+	connect      byte = 0x01
+	udpAssociate byte = 0x03
+
+	ipv4       byte = 0x01
+	domainName byte = 0x03
+	ipv6       byte = 0x04
+
+	maxPacketSize = 8192
+)
+
+var crlf = []byte{'\r', '\n'}
+
+//Trojan trojan client
+type Trojan struct {
+	password  []byte
+	tlsConfig *tls.Config
+
+	*utils.ClientUtil
+}
+
+//NewTrojan create new trojan client, the server is used as the tls server name if the sni is empty
+func NewTrojan(server, port, password, sni string, alpn []string, insecureSkipVerify bool) (proxy.Proxy, error) {
+	if password == "" {
+		return nil, errors.New("trojan password is empty")
+	}
+	if sni == "" {
+		sni = server
+	}
+	h := sha256.Sum224([]byte(password))
+	return &Trojan{
+		password: []byte(hex.EncodeToString(h[:])),
+		tlsConfig: &tls.Config{
+			ServerName:         sni,
+			NextProtos:         alpn,
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+		ClientUtil: utils.NewClientUtil(server, port),
+	}, nil
+}
+
+func (t *Trojan) conn(cmd byte, host string) (net.Conn, error) {
+	target, err := ss5client.ParseAddr(host)
+	if err != nil {
+		return nil, fmt.Errorf("parse host failed: %v", err)
+	}
+
+	conn, err := t.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("dial to trojan server failed: %v", err)
+	}
+	if x, ok := conn.(*net.TCPConn); ok {
+		_ = x.SetKeepAlive(true)
+	}
+
+	tlsConn := tls.Client(conn, t.tlsConfig)
+	if err = tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("tls handshake failed: %v", err)
+	}
+
+	// hex(SHA224(password)) CRLF CMD DST.ADDR DST.PORT CRLF
+	_, err = tlsConn.Write(bytes.Join([][]byte{t.password, crlf, {cmd}, target, crlf}, nil))
+	if err != nil {
+		tlsConn.Close()
+		return nil, fmt.Errorf("trojan write request failed: %v", err)
+	}
+	return tlsConn, nil
+}
+
+//Conn .
+func (t *Trojan) Conn(host string) (net.Conn, error) {
+	return t.conn(connect, host)
+}
+
+//PacketConn the udp packets are sent in the tls connection
+func (t *Trojan) PacketConn(host string) (net.PacketConn, error) {
+	conn, err := t.conn(udpAssociate, host)
+	if err != nil {
+		return nil, err
+	}
+	return &trojanPacketConn{Conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+// trojanPacketConn
+// ADDR.TYPE DST.ADDR DST.PORT LENGTH CRLF PAYLOAD
+type trojanPacketConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (t *trojanPacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	if len(b) > maxPacketSize {
+		return 0, fmt.Errorf("packet size %d is larger than %d", len(b), maxPacketSize)
+	}
+	target, err := ss5client.ParseAddr(addr.String())
+	if err != nil {
+		return 0, fmt.Errorf("parse target failed: %v", err)
+	}
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(b)))
+
+	if _, err = t.Conn.Write(bytes.Join([][]byte{target, length, crlf, b}, nil)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (t *trojanPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	host, port, err := readAddr(t.r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read address failed: %v", err)
+	}
+
+	head := make([]byte, 4)
+	if _, err = io.ReadFull(t.r, head); err != nil {
+		return 0, nil, fmt.Errorf("read length failed: %v", err)
+	}
+	length := int(binary.BigEndian.Uint16(head))
+
+	n := length
+	if n > len(b) {
+		n = len(b)
+	}
+	if _, err = io.ReadFull(t.r, b[:n]); err != nil {
+		return 0, nil, fmt.Errorf("read payload failed: %v", err)
+	}
+	// the rest of the packet is discarded if the buffer is too small
+	if _, err = t.r.Discard(length - n); err != nil {
+		return 0, nil, fmt.Errorf("discard payload failed: %v", err)
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return 0, nil, fmt.Errorf("resolve udp address failed: %v", err)
+	}
+	return n, addr, nil
+}
+
+func readAddr(r *bufio.Reader) (string, int, error) {
+	atyp, err := r.ReadByte()
+	if err != nil {
+		return "", 0, err
+	}
+
+	var host string
+	switch atyp {
+	case ipv4, ipv6:
+		ip := make([]byte, net.IPv4len)
+		if atyp == ipv6 {
+			ip = make([]byte, net.IPv6len)
+		}
+		if _, err = io.ReadFull(r, ip); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(ip).String()
+	case domainName:
+		l, err := r.ReadByte()
+		if err != nil {
+			return "", 0, err
+		}
+		domain := make([]byte, l)
+		if _, err = io.ReadFull(r, domain); err != nil {
+			return "", 0, err
+		}
+		host = string(domain)
+	default:
+		return "", 0, fmt.Errorf("unknown address type: %d", atyp)
+	}
+
+	port := make([]byte, 2)
+	if _, err = io.ReadFull(r, port); err != nil {
+		return "", 0, err
+	}
+	return host, int(binary.BigEndian.Uint16(port)), nil
+}
@@ -0,0 +1,102 @@
+package trojan
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
+)
+
+func TestImplement(t *testing.T) {
+	// make sure implement
+	var _ proxy.Proxy = new(Trojan)
+}
+
+//testServer a trojan server echoing the data, the udp packets are echoed with the same address
+func testServer(t *testing.T, password string) (string, string, chan tls.ConnectionState) {
+	config := proxytest.TLSConfig(t, "trojan.test")
+	config.NextProtos = []string{"h2", "http/1.1"}
+
+	h := sha256.Sum224([]byte(password))
+	hash := []byte(hex.EncodeToString(h[:]))
+	states := make(chan tls.ConnectionState, 10)
+	host, port := proxytest.Serve(t, config, func(c net.Conn) {
+		r := bufio.NewReader(c)
+		head := make([]byte, len(hash)+3)
+		if _, err := io.ReadFull(r, head); err != nil || !bytes.Equal(head[:len(hash)], hash) {
+			return
+		}
+		states <- c.(*tls.Conn).ConnectionState()
+		if _, _, err := readAddr(r); err != nil {
+			return
+		}
+		_, _ = r.Discard(2)
+		_, _ = io.Copy(c, r)
+	})
+	return host, port, states
+}
+
+func TestConn(t *testing.T) {
+	host, port, states := testServer(t, "test")
+
+	p, err := NewTrojan(host, port, "test", "trojan.test", []string{"h2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := p.Conn("example.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	proxytest.Echo(t, conn)
+
+	s := <-states
+	if s.ServerName != "trojan.test" || s.NegotiatedProtocol != "h2" {
+		t.Errorf("want sni trojan.test and alpn h2, got %s %s", s.ServerName, s.NegotiatedProtocol)
+	}
+
+	// the certificate isn't trusted
+	p, _ = NewTrojan(host, port, "test", "trojan.test", nil, false)
+	if _, err = p.Conn("example.com:443"); err == nil {
+		t.Error("want the certificate verify error")
+	}
+}
+
+func TestPacketConn(t *testing.T) {
+	host, port, _ := testServer(t, "test")
+
+	p, _ := NewTrojan(host, port, "test", "trojan.test", nil, true)
+	pc, err := p.PacketConn("1.1.1.1:53")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	target := &net.UDPAddr{IP: net.IPv4(1, 1, 1, 1), Port: 53}
+	for _, s := range []string{"ping", "pong"} {
+		if _, err = pc.WriteTo([]byte(s), target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b := make([]byte, 1024)
+	for _, s := range []string{"ping", "pong"} {
+		n, from, err := pc.ReadFrom(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b[:n]) != s || from.String() != target.String() {
+			t.Errorf("want %s from %v, got %s from %v", s, target, b[:n], from)
+		}
+	}
+
+	if _, err = pc.WriteTo(make([]byte, maxPacketSize+1), target); err == nil {
+		t.Error("want the error of the large packet")
+	}
+}
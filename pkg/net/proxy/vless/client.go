@@ -0,0 +1,285 @@
+package vless
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/grpc"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/quic"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/websocket"
+	"github.com/Asutorufa/yuhaiin/pkg/net/utils"
+)
+
+// https://xtls.github.io/development/protocols/vless.html
+const (
+	version byte = 0x00
+
+	commandTCP byte = 0x01
+	commandUDP byte = 0x02
+
+	ipv4       byte = 0x01
+	domainName byte = 0x02
+	ipv6       byte = 0x03
+)
+
+//Vless vless client
+type Vless struct {
+	address   string
+	port      string
+	uuid      []byte
+	net       string
+	tlsConfig *tls.Config
+
+	*utils.ClientUtil
+	getConn func() (net.Conn, error)
+}
+
+//NewVless create new vless client, the tls server name is the sni, or the ws host, or the address
+func NewVless(
+	address, port, uuid string,
+	netType, netHost, netPath string,
+	tlsEnable bool, sni string, alpn []string, insecureSkipVerify bool,
+) (proxy.Proxy, error) {
+	id, err := ParseUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Vless{
+		address:    address,
+		port:       port,
+		uuid:       id,
+		net:        netType,
+		ClientUtil: utils.NewClientUtil(address, port),
+	}
+
+	if sni == "" {
+		sni = netHost
+		if host, _, err := net.SplitHostPort(netHost); err == nil {
+			sni = host
+		}
+	}
+	if sni == "" {
+		sni = address
+	}
+	if tlsEnable {
+		v.tlsConfig = &tls.Config{
+			ServerName:         sni,
+			NextProtos:         alpn,
+			InsecureSkipVerify: insecureSkipVerify,
+		}
+	}
+
+	switch v.net {
+	case "ws":
+		if netHost == "" {
+			netHost = net.JoinHostPort(address, port)
+		}
+		v.getConn = websocket.NewClient(v.dial, netHost, netPath, insecureSkipVerify, false, nil).NewConn
+	case "quic":
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("convert port to int failed: %v", err)
+		}
+		// quic is always over tls
+		c, err := quic.NewClientWithTLS("udp", net.JoinHostPort(address, port), p, &tls.Config{
+			ServerName:         sni,
+			NextProtos:         alpn,
+			InsecureSkipVerify: insecureSkipVerify,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create new quic client failed: %v", err)
+		}
+		v.getConn = c.NewConn
+	case "grpc":
+		// the tls of grpc is handshaked by the grpc client, the path is the service name
+		c, err := grpc.NewClient(v.rawDial, net.JoinHostPort(address, port), netPath, v.tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("create new grpc client failed: %v", err)
+		}
+		v.getConn = c.NewConn
+	case "", "tcp":
+		v.getConn = v.dial
+	default:
+		return nil, fmt.Errorf("not support [net: %s] now", v.net)
+	}
+
+	return v, nil
+}
+
+//ParseUUID parse the uuid with or without the hyphens
+func ParseUUID(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != 16 {
+		return nil, fmt.Errorf("invalid uuid: %s", s)
+	}
+	return b, nil
+}
+
+//rawDial dial the server without tls
+func (v *Vless) rawDial() (net.Conn, error) {
+	conn, err := v.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("dial to %s failed: %v", v.address, err)
+	}
+	if x, ok := conn.(*net.TCPConn); ok {
+		_ = x.SetKeepAlive(true)
+	}
+	return conn, nil
+}
+
+//dial the server, the tls is handshaked if it is enabled, the websocket is created on it
+func (v *Vless) dial() (net.Conn, error) {
+	conn, err := v.rawDial()
+	if err != nil {
+		return nil, err
+	}
+	if v.tlsConfig == nil {
+		return conn, nil
+	}
+
+	tlsConn := tls.Client(conn, v.tlsConfig)
+	if err = tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("tls handshake failed: %v", err)
+	}
+	return tlsConn, nil
+}
+
+func (v *Vless) conn(cmd byte, host string) (*vlessConn, error) {
+	target, err := parseAddr(host)
+	if err != nil {
+		return nil, fmt.Errorf("parse host failed: %v", err)
+	}
+
+	conn, err := v.getConn()
+	if err != nil {
+		return nil, fmt.Errorf("get conn failed: %w", err)
+	}
+
+	// VERSION UUID ADDONS.LENGTH ADDONS CMD PORT ADDR.TYPE ADDR
+	_, err = conn.Write(bytes.Join([][]byte{{version}, v.uuid, {0, cmd}, target}, nil))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("vless write request failed: %v", err)
+	}
+	return &vlessConn{Conn: conn}, nil
+}
+
+//Conn create a connection for host
+func (v *Vless) Conn(host string) (net.Conn, error) {
+	return v.conn(commandTCP, host)
+}
+
+//PacketConn the packets are sent to the host only, the domain of the host is resolved by the server
+func (v *Vless) PacketConn(host string) (net.PacketConn, error) {
+	conn, err := v.conn(commandUDP, host)
+	if err != nil {
+		return nil, err
+	}
+	return &vlessPacketConn{vlessConn: conn, addr: &proxy.DomainAddr{Net: "udp", Addr: host}}, nil
+}
+
+// parseAddr PORT ADDR.TYPE ADDR
+func parseAddr(host string) ([]byte, error) {
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return nil, err
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s: %v", port, err)
+	}
+
+	b := make([]byte, 2, 3+len(hostname))
+	binary.BigEndian.PutUint16(b, uint16(p))
+	if ip := net.ParseIP(hostname); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return append(append(b, ipv4), ip4...), nil
+		}
+		return append(append(b, ipv6), ip.To16()...), nil
+	}
+	if len(hostname) > 255 {
+		return nil, fmt.Errorf("domain %s is too long", hostname)
+	}
+	return append(append(b, domainName, byte(len(hostname))), hostname...), nil
+}
+
+// vlessConn read the response header before the first read
+// VERSION ADDONS.LENGTH ADDONS
+type vlessConn struct {
+	net.Conn
+	once sync.Once
+	err  error
+}
+
+func (v *vlessConn) Read(b []byte) (int, error) {
+	v.once.Do(func() {
+		head := make([]byte, 2)
+		if _, v.err = io.ReadFull(v.Conn, head); v.err != nil {
+			v.err = fmt.Errorf("read response failed: %v", v.err)
+			return
+		}
+		if head[0] != version {
+			v.err = fmt.Errorf("unknown vless version: %d", head[0])
+			return
+		}
+		if _, v.err = io.CopyN(io.Discard, v.Conn, int64(head[1])); v.err != nil {
+			v.err = fmt.Errorf("read response addons failed: %v", v.err)
+		}
+	})
+	if v.err != nil {
+		return 0, v.err
+	}
+	return v.Conn.Read(b)
+}
+
+// vlessPacketConn
+// LENGTH PAYLOAD
+type vlessPacketConn struct {
+	*vlessConn
+	addr net.Addr
+}
+
+func (v *vlessPacketConn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	if len(b) > 0xffff {
+		return 0, errors.New("packet is too large")
+	}
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(b)))
+	if _, err := v.vlessConn.Write(append(length, b...)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (v *vlessPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(v.vlessConn, length); err != nil {
+		return 0, nil, fmt.Errorf("read length failed: %v", err)
+	}
+	l := int(binary.BigEndian.Uint16(length))
+
+	n := l
+	if n > len(b) {
+		n = len(b)
+	}
+	if _, err := io.ReadFull(v.vlessConn, b[:n]); err != nil {
+		return 0, nil, fmt.Errorf("read payload failed: %v", err)
+	}
+	// the rest of the packet is discarded if the buffer is too small
+	if _, err := io.CopyN(io.Discard, v.vlessConn, int64(l-n)); err != nil {
+		return 0, nil, fmt.Errorf("discard payload failed: %v", err)
+	}
+	return n, v.addr, nil
+}
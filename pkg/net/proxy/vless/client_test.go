@@ -0,0 +1,263 @@
+package vless
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxytest"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testUUID = "b831381d-6324-4d53-ad4f-8cda48b30811"
+
+func TestImplement(t *testing.T) {
+	// make sure implement
+	var _ proxy.Proxy = new(Vless)
+}
+
+//readRequest read the request header, return the command and the target
+func readRequest(r io.Reader) (byte, string, error) {
+	id, _ := ParseUUID(testUUID)
+	head := make([]byte, 1+16+1+1+2+1)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return 0, "", err
+	}
+	if head[0] != version || !bytes.Equal(head[1:17], id) || head[17] != 0 {
+		return 0, "", fmt.Errorf("invalid request: %v", head)
+	}
+
+	var host []byte
+	switch head[21] {
+	case ipv4:
+		host = make([]byte, net.IPv4len)
+	case ipv6:
+		host = make([]byte, net.IPv6len)
+	case domainName:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(r, l); err != nil {
+			return 0, "", err
+		}
+		host = make([]byte, l[0])
+	}
+	if _, err := io.ReadFull(r, host); err != nil {
+		return 0, "", err
+	}
+	if head[21] != domainName {
+		host = []byte(net.IP(host).String())
+	}
+	return head[18], net.JoinHostPort(string(host), fmt.Sprint(binary.BigEndian.Uint16(head[19:21]))), nil
+}
+
+//testServer a vless server echoing the data, the connections are tls if config isn't nil
+func testServer(t *testing.T, config *tls.Config) (string, string, chan string) {
+	targets := make(chan string, 10)
+	host, port := proxytest.Serve(t, config, func(c net.Conn) {
+		r := bufio.NewReader(c)
+		_, target, err := readRequest(r)
+		if err != nil {
+			return
+		}
+		targets <- target
+		_, _ = c.Write([]byte{version, 0})
+		_, _ = io.Copy(c, r)
+	})
+	return host, port, targets
+}
+
+func echo(t *testing.T, p proxy.Proxy, targets chan string) {
+	conn, err := p.Conn("example.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	proxytest.Echo(t, conn)
+	if x := <-targets; x != "example.com:443" {
+		t.Errorf("want example.com:443, got %s", x)
+	}
+}
+
+func TestConn(t *testing.T) {
+	host, port, targets := testServer(t, nil)
+	p, err := NewVless(host, port, testUUID, "tcp", "", "", false, "", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, p, targets)
+
+	host, port, targets = testServer(t, proxytest.TLSConfig(t, "vless.test"))
+	p, _ = NewVless(host, port, testUUID, "", "", "", true, "vless.test", nil, true)
+	echo(t, p, targets)
+
+	// the certificate isn't trusted
+	p, _ = NewVless(host, port, testUUID, "", "", "", true, "vless.test", nil, false)
+	if _, err = p.Conn("example.com:443"); err == nil {
+		t.Error("want the certificate verify error")
+	}
+
+	if _, err = NewVless(host, port, "invalid", "", "", "", false, "", nil, false); err == nil {
+		t.Error("want the error of the invalid uuid")
+	}
+}
+
+func TestWebsocket(t *testing.T) {
+	targets := make(chan string, 10)
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vless", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for first := true; ; first = false {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if first {
+				x := bytes.NewReader(data)
+				_, target, err := readRequest(x)
+				if err != nil {
+					return
+				}
+				targets <- target
+				data = append([]byte{version, 0}, data[len(data)-x.Len():]...)
+			}
+			if err = c.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				return
+			}
+		}
+	})
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", proxytest.TLSConfig(t, "vless.test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() { _ = http.Serve(lis, mux) }()
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	p, err := NewVless(host, port, testUUID, "ws", "vless.test", "/vless", true, "", nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, p, targets)
+}
+
+//grpcStream the reader of the messages of the grpc stream
+type grpcStream struct {
+	grpc.ServerStream
+	buf []byte
+}
+
+func (g *grpcStream) Read(b []byte) (int, error) {
+	if len(g.buf) == 0 {
+		h := &wrapperspb.BytesValue{}
+		if err := g.RecvMsg(h); err != nil {
+			return 0, err
+		}
+		g.buf = h.Value
+	}
+	n := copy(b, g.buf)
+	g.buf = g.buf[n:]
+	return n, nil
+}
+
+func (g *grpcStream) Write(b []byte) (int, error) {
+	return len(b), g.SendMsg(&wrapperspb.BytesValue{Value: b})
+}
+
+func TestGrpc(t *testing.T) {
+	targets := make(chan string, 10)
+	s := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(proxytest.TLSConfig(t, "vless.test"))),
+		grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+			if x, _ := grpc.MethodFromServerStream(stream); x != "/vless/Tun" {
+				return fmt.Errorf("unknown method: %s", x)
+			}
+			g := &grpcStream{ServerStream: stream}
+			_, target, err := readRequest(g)
+			if err != nil {
+				return err
+			}
+			targets <- target
+			if _, err = g.Write([]byte{version, 0}); err != nil {
+				return err
+			}
+			_, err = io.Copy(g, g)
+			return err
+		}),
+	)
+	defer s.Stop()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.Serve(lis) }()
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	p, err := NewVless(host, port, testUUID, "grpc", "", "vless", true, "vless.test", []string{"h2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, p, targets)
+}
+
+func TestPacketConn(t *testing.T) {
+	host, port, targets := testServer(t, nil)
+	p, _ := NewVless(host, port, testUUID, "tcp", "", "", false, "", nil, false)
+
+	pc, err := p.PacketConn("1.1.1.1:53")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	for _, s := range []string{"ping", "pong"} {
+		if _, err = pc.WriteTo([]byte(s), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b := make([]byte, 1024)
+	for _, s := range []string{"ping", "pong"} {
+		n, from, err := pc.ReadFrom(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b[:n]) != s || from.String() != "1.1.1.1:53" {
+			t.Errorf("want %s from 1.1.1.1:53, got %s from %v", s, b[:n], from)
+		}
+	}
+	if x := <-targets; x != "1.1.1.1:53" {
+		t.Errorf("want 1.1.1.1:53, got %s", x)
+	}
+
+	// the domain is sent to the server without resolving
+	pc, err = p.PacketConn("dns.invalid:53")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	if _, err = pc.WriteTo([]byte("ping"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, from, err := pc.ReadFrom(b); err != nil || from.String() != "dns.invalid:53" {
+		t.Errorf("want the packet from dns.invalid:53, got %v %v", from, err)
+	}
+	if x := <-targets; x != "dns.invalid:53" {
+		t.Errorf("want dns.invalid:53, got %s", x)
+	}
+}
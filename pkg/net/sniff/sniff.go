@@ -0,0 +1,220 @@
+package sniff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+//Timeout the max time to wait the first bytes from client,
+//the server-first protocols (eg: ssh, smtp) send nothing, so don't wait too long,
+//the tls and http clients send the request as soon as the connection is established
+var Timeout = 50 * time.Millisecond
+
+//ServerFirstPorts the well-known ports of the server-first protocols, they aren't sniffed
+var ServerFirstPorts = map[string]bool{
+	"21":   true, // ftp
+	"22":   true, // ssh
+	"23":   true, // telnet
+	"25":   true, // smtp
+	"110":  true, // pop3
+	"143":  true, // imap
+	"587":  true, // smtp submission
+	"3306": true, // mysql
+	"5900": true, // vnc
+}
+
+//Skip whether addr is a server-first port, the client of it sends nothing, so sniffing only stalls the connection
+func Skip(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && ServerFirstPorts[port]
+}
+
+const maxPeekSize = 8 * 0x400
+
+var errNeedMore = errors.New("need more data")
+
+//Sniff peek the first bytes of c for the TLS SNI or the HTTP Host,
+//return the domain (empty if not found) and a conn that replays the peeked bytes
+func Sniff(c net.Conn) (string, net.Conn) {
+	buf := make([]byte, maxPeekSize)
+	n := 0
+
+	_ = c.SetReadDeadline(time.Now().Add(Timeout))
+	defer c.SetReadDeadline(time.Time{})
+
+	domain := ""
+	for n < len(buf) {
+		x, err := c.Read(buf[n:])
+		n += x
+		if x > 0 {
+			d, e := sniff(buf[:n])
+			if e == nil {
+				domain = d
+				break
+			}
+			if e != errNeedMore {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if net.ParseIP(domain) != nil {
+		domain = ""
+	}
+
+	if n == 0 {
+		return domain, c
+	}
+	return domain, &replayConn{Conn: c, r: io.MultiReader(bytes.NewReader(buf[:n]), c)}
+}
+
+//Addr rewrite addr to domain:port, return addr if domain is empty
+func Addr(addr, domain string) string {
+	if domain == "" {
+		return addr
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return net.JoinHostPort(domain, port)
+}
+
+func sniff(b []byte) (string, error) {
+	if b[0] == 0x16 { // tls handshake
+		return sniffTLS(b)
+	}
+	return sniffHTTP(b)
+}
+
+type replayConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (r *replayConn) Read(b []byte) (int, error) { return r.r.Read(b) }
+
+var errNotFound = errors.New("not found")
+
+//sniffTLS get the server name from the ClientHello
+//https://datatracker.ietf.org/doc/html/rfc8446#section-4.1.2
+func sniffTLS(b []byte) (string, error) {
+	if len(b) < 5 {
+		return "", errNeedMore
+	}
+	recordLen := int(binary.BigEndian.Uint16(b[3:5]))
+	if len(b) < 5+recordLen {
+		return "", errNeedMore
+	}
+	b = b[5 : 5+recordLen]
+
+	// handshake type(1) length(3) version(2) random(32)
+	if len(b) < 38 || b[0] != 0x01 {
+		return "", errNotFound
+	}
+	b = b[38:]
+
+	// session id
+	if len(b) < 1 || len(b) < 1+int(b[0]) {
+		return "", errNotFound
+	}
+	b = b[1+int(b[0]):]
+
+	// cipher suites
+	if len(b) < 2 || len(b) < 2+int(binary.BigEndian.Uint16(b)) {
+		return "", errNotFound
+	}
+	b = b[2+int(binary.BigEndian.Uint16(b)):]
+
+	// compression methods
+	if len(b) < 1 || len(b) < 1+int(b[0]) {
+		return "", errNotFound
+	}
+	b = b[1+int(b[0]):]
+
+	// extensions
+	if len(b) < 2 {
+		return "", errNotFound
+	}
+	b = b[2:]
+	for len(b) >= 4 {
+		typ := binary.BigEndian.Uint16(b)
+		l := int(binary.BigEndian.Uint16(b[2:]))
+		if len(b) < 4+l {
+			return "", errNotFound
+		}
+		data := b[4 : 4+l]
+		b = b[4+l:]
+
+		if typ != 0x00 { // server_name
+			continue
+		}
+
+		// server name list length(2), name type(1), name length(2)
+		if len(data) < 5 || data[2] != 0x00 {
+			return "", errNotFound
+		}
+		nl := int(binary.BigEndian.Uint16(data[3:]))
+		if len(data) < 5+nl {
+			return "", errNotFound
+		}
+		return strings.ToLower(string(data[5 : 5+nl])), nil
+	}
+	return "", errNotFound
+}
+
+var methods = []string{"GET ", "POST ", "HEAD ", "PUT ", "DELETE ", "OPTIONS ", "PATCH ", "CONNECT ", "TRACE "}
+
+//sniffHTTP get the Host header of the http request
+func sniffHTTP(b []byte) (string, error) {
+	isHTTP := false
+	for _, m := range methods {
+		if len(b) < len(m) {
+			if strings.HasPrefix(m, string(b)) {
+				return "", errNeedMore
+			}
+			continue
+		}
+		if string(b[:len(m)]) == m {
+			isHTTP = true
+			break
+		}
+	}
+	if !isHTTP {
+		return "", errNotFound
+	}
+
+	end := bytes.Index(b, []byte("\r\n\r\n"))
+	if end == -1 {
+		end = len(b)
+	}
+
+	lines := strings.Split(string(b[:end]), "\r\n")
+	for i, l := range lines[1:] {
+		if i == len(lines)-2 && end == len(b) {
+			break // the last line may be incomplete
+		}
+		k := strings.IndexByte(l, ':')
+		if k == -1 || !strings.EqualFold(strings.TrimSpace(l[:k]), "host") {
+			continue
+		}
+		host := strings.TrimSpace(l[k+1:])
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		return strings.ToLower(strings.Trim(host, "[]")), nil
+	}
+
+	if end == len(b) {
+		return "", errNeedMore
+	}
+	return "", errNotFound
+}
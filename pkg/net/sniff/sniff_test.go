@@ -0,0 +1,88 @@
+package sniff
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSniffTLS(t *testing.T) {
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+
+	go func() {
+		_ = tls.Client(c, &tls.Config{ServerName: "www.example.com"}).Handshake()
+	}()
+
+	domain, conn := Sniff(s)
+	if domain != "www.example.com" {
+		t.Errorf("want www.example.com, got %s", domain)
+	}
+
+	b := make([]byte, 1)
+	if _, err := conn.Read(b); err != nil || b[0] != 0x16 {
+		t.Errorf("the peeked bytes should be replayed, got %v %v", b, err)
+	}
+}
+
+func TestSniffHTTP(t *testing.T) {
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://www.example.com:8080/index.html", nil)
+		_ = req.Write(c)
+		c.Close()
+	}()
+
+	domain, conn := Sniff(s)
+	if domain != "www.example.com" {
+		t.Errorf("want www.example.com, got %s", domain)
+	}
+
+	data, _ := ioutil.ReadAll(conn)
+	if !strings.HasPrefix(string(data), "GET /index.html HTTP/1.1\r\n") {
+		t.Errorf("the peeked bytes should be replayed, got %s", data)
+	}
+
+	if x := Addr("1.1.1.1:8080", domain); x != "www.example.com:8080" {
+		t.Errorf("want www.example.com:8080, got %s", x)
+	}
+}
+
+func TestSniffOthers(t *testing.T) {
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+
+	go func() { _, _ = c.Write([]byte("SSH-2.0-OpenSSH_8.4\r\n")) }()
+
+	domain, _ := Sniff(s)
+	if domain != "" {
+		t.Errorf("want empty, got %s", domain)
+	}
+
+	// server-first protocols send nothing
+	domain, _ = Sniff(s)
+	if domain != "" {
+		t.Errorf("want empty, got %s", domain)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"1.1.1.1:22":  true,
+		"1.1.1.1:25":  true,
+		"1.1.1.1:443": false,
+		"1.1.1.1":     false,
+	} {
+		if Skip(addr) != want {
+			t.Errorf("want %v of %s, got %v", want, addr, !want)
+		}
+	}
+}
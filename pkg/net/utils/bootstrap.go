@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+var bootstrap atomic.Value
+
+//SetBootstrap set the lookup of ClientUtil resolving the server address, nil for the system resolver,
+//it must not go through the proxy, otherwise resolving the server address of the node loops
+func SetBootstrap(f func(string) ([]net.IP, error)) {
+	if f == nil {
+		f = SystemLookup
+	}
+	bootstrap.Store(f)
+}
+
+//Bootstrap resolve the domain by the lookup set by SetBootstrap
+func Bootstrap(domain string) ([]net.IP, error) {
+	if f, ok := bootstrap.Load().(func(string) ([]net.IP, error)); ok {
+		return f(domain)
+	}
+	return SystemLookup(domain)
+}
+
+//SystemLookup resolve the domain by the system resolver
+func SystemLookup(domain string) ([]net.IP, error) {
+	return LookupIP(net.DefaultResolver, domain)
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"net"
+	"testing"
+)
+
+func TestBootstrap(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	var lookup []string
+	SetBootstrap(func(domain string) ([]net.IP, error) {
+		lookup = append(lookup, domain)
+		return []net.IP{net.ParseIP("127.0.0.1")}, nil
+	})
+	defer SetBootstrap(nil)
+
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	conn, err := NewClientUtil("node.example.com", port).GetConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if len(lookup) != 1 || lookup[0] != "node.example.com" {
+		t.Errorf("want the server address resolved by bootstrap, got %v", lookup)
+	}
+}
@@ -96,6 +96,29 @@ func (l *LRU) Load(key interface{}) (interface{}, bool) {
 	return y.data, true
 }
 
+//Range call f for the entries from the most recently used until f returns false, the expired are skipped,
+//f shouldn't call the methods of the lru
+func (l *LRU) Range(f func(key, value interface{}) bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for e := l.list.Front(); e != nil; e = e.Next() {
+		y := e.Value.(*lruEntry)
+		if l.timeout != 0 && time.Since(y.store) >= l.timeout {
+			continue
+		}
+		if !f(y.key, y.data) {
+			return
+		}
+	}
+}
+
+//Len the count of the entries, including the expired
+func (l *LRU) Len() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.list.Len()
+}
+
 // Cache use map save history
 type Cache struct {
 	number         int
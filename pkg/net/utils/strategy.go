@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+//Strategy the address family strategy of the dns resolution
+type Strategy int32
+
+const (
+	//PreferIPv4 query both A and AAAA, the ipv4 addresses first
+	PreferIPv4 Strategy = 0
+	//IPv4Only query A only
+	IPv4Only Strategy = 1
+	//IPv6Only query AAAA only
+	IPv6Only Strategy = 2
+	//PreferIPv6 query both A and AAAA, the ipv6 addresses first
+	PreferIPv6 Strategy = 3
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case IPv4Only:
+		return "ipv4-only"
+	case IPv6Only:
+		return "ipv6-only"
+	case PreferIPv6:
+		return "prefer-ipv6"
+	default:
+		return "prefer-ipv4"
+	}
+}
+
+//UseIPv4 whether the A records are needed
+func (s Strategy) UseIPv4() bool { return s != IPv6Only }
+
+//UseIPv6 whether the AAAA records are needed
+func (s Strategy) UseIPv6() bool { return s != IPv4Only }
+
+//Apply filter and sort the ips by the strategy, the order in the same family is kept
+func (s Strategy) Apply(ips []net.IP) []net.IP {
+	v4 := make([]net.IP, 0, len(ips))
+	v6 := make([]net.IP, 0, len(ips))
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			v4 = append(v4, ip)
+		} else if ip.To16() != nil {
+			v6 = append(v6, ip)
+		}
+	}
+
+	switch s {
+	case IPv4Only:
+		return v4
+	case IPv6Only:
+		return v6
+	case PreferIPv6:
+		return append(v6, v4...)
+	default:
+		return append(v4, v6...)
+	}
+}
+
+var defaultStrategy int32
+
+//SetStrategy set the strategy of ClientUtil resolving the server address
+func SetStrategy(s Strategy) { atomic.StoreInt32(&defaultStrategy, int32(s)) }
+
+//GetStrategy get the strategy set by SetStrategy
+func GetStrategy() Strategy { return Strategy(atomic.LoadInt32(&defaultStrategy)) }
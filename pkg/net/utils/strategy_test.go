@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"net"
+	"testing"
+)
+
+func TestStrategy(t *testing.T) {
+	ips := []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1"), net.ParseIP("fe80::1"), net.ParseIP("10.0.0.1")}
+
+	if x := IPv4Only.Apply(ips); len(x) != 2 || !x[0].Equal(ips[1]) {
+		t.Error(IPv4Only, x)
+	}
+	if x := IPv6Only.Apply(ips); len(x) != 2 || !x[1].Equal(ips[2]) {
+		t.Error(IPv6Only, x)
+	}
+	if x := PreferIPv4.Apply(ips); len(x) != 4 || !x[1].Equal(ips[3]) || !x[2].Equal(ips[0]) {
+		t.Error(PreferIPv4, x)
+	}
+	if x := PreferIPv6.Apply(ips); len(x) != 4 || !x[0].Equal(ips[0]) || !x[3].Equal(ips[3]) {
+		t.Error(PreferIPv6, x)
+	}
+}
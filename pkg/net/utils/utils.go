@@ -61,6 +61,7 @@ type ClientUtil struct {
 	port     int
 	host     string
 	tcpCache []*net.TCPAddr
+	lookup   func(string) ([]net.IP, error)
 	lock     sync.RWMutex
 }
 
@@ -74,8 +75,30 @@ func NewClientUtil(address, port string) *ClientUtil {
 	}
 }
 
+//SetLookup set the lookup of the server address, the default is Bootstrap
+func (c *ClientUtil) SetLookup(f func(string) ([]net.IP, error)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lookup = f
+}
+
 func (c *ClientUtil) lookUp(s string) ([]net.IP, error) {
-	return LookupIP(net.DefaultResolver, s)
+	c.lock.RLock()
+	lookup := c.lookup
+	c.lock.RUnlock()
+	if lookup == nil {
+		lookup = Bootstrap
+	}
+
+	ips, err := lookup(s)
+	if err != nil {
+		return nil, err
+	}
+	ips = GetStrategy().Apply(ips)
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no %s address of %s", GetStrategy(), s)
+	}
+	return ips, nil
 }
 
 func (c *ClientUtil) dial() (net.Conn, error) {
@@ -122,7 +145,7 @@ func (c *ClientUtil) refreshCache() {
 
 	tcpCache := make([]*net.TCPAddr, 0, len(x))
 	for i := range x {
-		tcpCache = append(c.tcpCache, &net.TCPAddr{IP: x[i], Port: c.port})
+		tcpCache = append(tcpCache, &net.TCPAddr{IP: x[i], Port: c.port})
 	}
 
 	c.lock.Lock()
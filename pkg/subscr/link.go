@@ -0,0 +1,66 @@
+package subscr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+//parseURL parse the link with one of the schemes
+func parseURL(str []byte, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(string(str))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s link failed: %v", schemes[0], err)
+	}
+	for _, x := range schemes {
+		if u.Scheme == x {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s link: %s", schemes[0], str)
+}
+
+//parseAlpn the alpn split by the comma, eg: alpn=h2,http/1.1
+func parseAlpn(q url.Values) []string {
+	var alpn []string
+	for _, x := range strings.Split(q.Get("alpn"), ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			alpn = append(alpn, x)
+		}
+	}
+	return alpn
+}
+
+//parseAllowInsecure allowInsecure=1 or allowInsecure=true
+func parseAllowInsecure(q url.Values) bool {
+	switch strings.ToLower(q.Get("allowInsecure")) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
+
+//newPoint the remote point of the node, the hash is the sha256 of the point
+func newPoint(group, name string, node isPoint_Node) *Point {
+	p := &Point{
+		NOrigin: Point_remote,
+		NGroup:  group,
+		NName:   name,
+		Node:    node,
+	}
+	z := sha256.Sum256([]byte(p.String()))
+	p.NHash = hex.EncodeToString(z[:])
+	return p
+}
+
+//parseLinkManual parse the link by parse, the point is marked manual
+func parseLinkManual(parse func([]byte, string) (*Point, error), link []byte, group string) (*Point, error) {
+	p, err := parse(link, group)
+	if err != nil {
+		return nil, err
+	}
+	p.NOrigin = Point_manual
+	return p, nil
+}
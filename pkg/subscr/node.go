@@ -30,6 +30,7 @@ type NodeManager struct {
 	configPath string
 	lock       sync.RWMutex
 	filelock   sync.RWMutex
+	outbounds  sync.Map
 	proxy.Proxy
 }
 
@@ -134,6 +135,50 @@ func (n *NodeManager) ChangeNowNode(c context.Context, s *wrapperspb.StringValue
 	return n.node.NowNode, err
 }
 
+// Outbound get the proxy of the node with hash, if hash is empty, use the node of group,
+// the now node is preferred when it belongs to the group, otherwise the first node of the group
+func (n *NodeManager) Outbound(hash, group string) (proxy.Proxy, error) {
+	n.lock.RLock()
+	now := n.node.NowNode.NHash
+	if hash == "" {
+		g, ok := n.node.GroupNodesMap[group]
+		if !ok || len(g.Nodes) == 0 {
+			n.lock.RUnlock()
+			return nil, fmt.Errorf("group %v is not exist", group)
+		}
+
+		hash = g.NodeHashMap[g.Nodes[0]]
+		for _, x := range g.NodeHashMap {
+			if x == now {
+				hash = now
+				break
+			}
+		}
+	}
+	n.lock.RUnlock()
+
+	if hash == now {
+		return n.Proxy, nil
+	}
+
+	if p, ok := n.outbounds.Load(hash); ok {
+		return p.(proxy.Proxy), nil
+	}
+
+	p, err := n.GetNode(context.TODO(), &wrapperspb.StringValue{Value: hash})
+	if err != nil {
+		return nil, fmt.Errorf("get node failed: %v", err)
+	}
+
+	px, err := ParseNodeConn(p)
+	if err != nil {
+		return nil, fmt.Errorf("parse node %v failed: %v", hash, err)
+	}
+
+	n.outbounds.Store(hash, px)
+	return px, nil
+}
+
 func (n *NodeManager) RefreshSubscr(c context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
 	if n.node.Links == nil {
 		n.node.Links = make(map[string]*NodeLink)
@@ -218,6 +263,7 @@ func (n *NodeManager) deleteRemoteNodes(group string) {
 			continue
 		}
 
+		n.outbounds.Delete(msmap[ns[i]])
 		delete(n.node.Nodes, msmap[ns[i]])
 		delete(n.node.GroupNodesMap[group].NodeHashMap, ns[i])
 	}
@@ -260,6 +306,30 @@ func parseUrl(str []byte, group string) (node *Point, err error) {
 			return nil, err
 		}
 		return node, nil
+	case bytes.HasPrefix(str, []byte("trojan://")):
+		node, err := DefaultTrojan.ParseLink(str, group)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case bytes.HasPrefix(str, []byte("vless://")):
+		node, err := DefaultVless.ParseLink(str, group)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case bytes.HasPrefix(str, []byte("socks5://")):
+		node, err := DefaultSocks5.ParseLink(str, group)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
+	case bytes.HasPrefix(str, []byte("http://")), bytes.HasPrefix(str, []byte("https://")):
+		node, err := DefaultHttp.ParseLink(str, group)
+		if err != nil {
+			return nil, err
+		}
+		return node, nil
 	default:
 		return nil, errors.New("no support " + string(str))
 	}
@@ -273,6 +343,7 @@ func (n *NodeManager) DeleteNode(_ context.Context, s *wrapperspb.StringValue) (
 		return &emptypb.Empty{}, nil
 	}
 
+	n.outbounds.Delete(s.Value)
 	delete(n.node.GroupNodesMap[p.NGroup].NodeHashMap, p.NName)
 
 	for i, x := range n.node.GroupNodesMap[p.NGroup].Nodes {
@@ -399,6 +470,14 @@ func ParseNodeConn(s *Point) (proxy.Proxy, error) {
 		return DefaultShadowsocksr.ParseConn(s)
 	case *Point_Vmess:
 		return DefaultVmess.ParseConn(s)
+	case *Point_Trojan:
+		return DefaultTrojan.ParseConn(s)
+	case *Point_Vless:
+		return DefaultVless.ParseConn(s)
+	case *Point_Socks5:
+		return DefaultSocks5.ParseConn(s)
+	case *Point_Http:
+		return DefaultHttp.ParseConn(s)
 	}
 
 	return nil, errors.New("not support type")
@@ -0,0 +1,60 @@
+package subscr
+
+import (
+	"fmt"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	libTrojan "github.com/Asutorufa/yuhaiin/pkg/net/proxy/trojan"
+)
+
+var DefaultTrojan = &trojan{}
+
+type trojan struct{}
+
+//ParseLink parse trojan link
+// eg: trojan://password@example.com:443?sni=www.example.com&alpn=h2,http/1.1&allowInsecure=1#name
+func (*trojan) ParseLink(str []byte, group string) (*Point, error) {
+	u, err := parseURL(str, "trojan")
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Trojan{
+		Server:   u.Hostname(),
+		Port:     u.Port(),
+		Password: u.User.Username(),
+		Sni:      u.Query().Get("sni"),
+	}
+	if n.Sni == "" {
+		n.Sni = u.Query().Get("peer")
+	}
+	if n.Port == "" {
+		n.Port = "443"
+	}
+	if n.Server == "" || n.Password == "" {
+		return nil, fmt.Errorf("trojan link without server or password: %s", str)
+	}
+	n.Alpn = parseAlpn(u.Query())
+	n.AllowInsecure = parseAllowInsecure(u.Query())
+
+	return newPoint(group, "[trojan]"+u.Fragment, &Point_Trojan{Trojan: n}), nil
+}
+
+// ParseLinkManual parse a manual trojan link
+func (t *trojan) ParseLinkManual(link []byte, group string) (*Point, error) {
+	return parseLinkManual(t.ParseLink, link, group)
+}
+
+//ParseConn parse trojan point to proxy
+func (*trojan) ParseConn(n *Point) (proxy.Proxy, error) {
+	x := n.GetTrojan()
+	if x == nil {
+		return nil, fmt.Errorf("can't get trojan message")
+	}
+
+	t, err := libTrojan.NewTrojan(x.Server, x.Port, x.Password, x.Sni, x.Alpn, x.AllowInsecure)
+	if err != nil {
+		return nil, fmt.Errorf("new trojan failed: %v", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,38 @@
+package subscr
+
+import (
+	"testing"
+)
+
+func TestParseTrojan(t *testing.T) {
+	p, err := parseUrl([]byte("trojan://pass%40word@example.com:8443?sni=www.example.com&alpn=h2,http/1.1&allowInsecure=1#name"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(p)
+
+	x := p.GetTrojan()
+	if x == nil || p.NName != "[trojan]name" || p.NHash == "" {
+		t.Fatalf("want the trojan point, got %v", p)
+	}
+	if x.Server != "example.com" || x.Port != "8443" || x.Password != "pass@word" ||
+		x.Sni != "www.example.com" || len(x.Alpn) != 2 || !x.AllowInsecure {
+		t.Errorf("parse trojan link failed: %v", x)
+	}
+
+	if _, err = ParseNodeConn(p); err != nil {
+		t.Error(err)
+	}
+
+	p, err = DefaultTrojan.ParseLinkManual([]byte("trojan://password@example.com"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x = p.GetTrojan(); x.Port != "443" || x.Sni != "" || x.AllowInsecure || p.NOrigin != Point_manual {
+		t.Errorf("want the default port and tls options, got %v", p)
+	}
+
+	if _, err = parseUrl([]byte("trojan://example.com:443"), "test"); err == nil {
+		t.Error("want the error of the link without password")
+	}
+}
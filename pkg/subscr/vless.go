@@ -0,0 +1,87 @@
+package subscr
+
+import (
+	"fmt"
+
+	"github.com/Asutorufa/yuhaiin/pkg/net/proxy/proxy"
+	libVless "github.com/Asutorufa/yuhaiin/pkg/net/proxy/vless"
+)
+
+var DefaultVless = &vless{}
+
+type vless struct{}
+
+//ParseLink parse vless link, the serviceName of grpc is saved as the path
+// eg: vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:443?encryption=none&security=tls&sni=www.example.com&type=ws&host=www.example.com&path=%2Fvless#name
+//     vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:443?security=tls&type=grpc&serviceName=vless#name
+func (*vless) ParseLink(str []byte, group string) (*Point, error) {
+	u, err := parseURL(str, "vless")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+
+	n := &Vless{
+		Server: u.Hostname(),
+		Port:   u.Port(),
+		Uuid:   u.User.Username(),
+		Net:    q.Get("type"),
+		Host:   q.Get("host"),
+		Path:   q.Get("path"),
+		Sni:    q.Get("sni"),
+	}
+	if n.Net == "grpc" {
+		n.Path = q.Get("serviceName")
+	}
+	if n.Port == "" {
+		n.Port = "443"
+	}
+	if _, err = libVless.ParseUUID(n.Uuid); err != nil || n.Server == "" {
+		return nil, fmt.Errorf("vless link without server or uuid: %s", str)
+	}
+	switch n.Net {
+	case "", "tcp", "ws", "quic", "grpc":
+	default:
+		return nil, fmt.Errorf("not support vless [type: %s] now", n.Net)
+	}
+	if x := q.Get("encryption"); x != "" && x != "none" {
+		return nil, fmt.Errorf("not support vless [encryption: %s] now", x)
+	}
+	if x := q.Get("flow"); x != "" {
+		return nil, fmt.Errorf("not support vless [flow: %s] now", x)
+	}
+	switch x := q.Get("security"); x {
+	case "", "none":
+	case "tls":
+		n.Tls = true
+	default:
+		return nil, fmt.Errorf("not support vless [security: %s] now", x)
+	}
+	n.Alpn = parseAlpn(q)
+	n.AllowInsecure = parseAllowInsecure(q)
+
+	return newPoint(group, "[vless]"+u.Fragment, &Point_Vless{Vless: n}), nil
+}
+
+// ParseLinkManual parse a manual vless link
+func (v *vless) ParseLinkManual(link []byte, group string) (*Point, error) {
+	return parseLinkManual(v.ParseLink, link, group)
+}
+
+//ParseConn parse vless point to proxy
+func (*vless) ParseConn(n *Point) (proxy.Proxy, error) {
+	x := n.GetVless()
+	if x == nil {
+		return nil, fmt.Errorf("can't get vless message")
+	}
+
+	v, err := libVless.NewVless(
+		x.Server, x.Port, x.Uuid,
+		x.Net, x.Host, x.Path,
+		x.Tls, x.Sni, x.Alpn, x.AllowInsecure,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("new vless failed: %v", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,57 @@
+package subscr
+
+import (
+	"testing"
+)
+
+func TestParseVless(t *testing.T) {
+	p, err := parseUrl([]byte("vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:8443?encryption=none&security=tls&sni=www.example.com&alpn=h2,http/1.1&type=ws&host=ws.example.com&path=%2Fvless&allowInsecure=1#name"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(p)
+
+	x := p.GetVless()
+	if x == nil || p.NName != "[vless]name" || p.NHash == "" {
+		t.Fatalf("want the vless point, got %v", p)
+	}
+	if x.Server != "example.com" || x.Port != "8443" || x.Uuid != "b831381d-6324-4d53-ad4f-8cda48b30811" ||
+		x.Net != "ws" || x.Host != "ws.example.com" || x.Path != "/vless" ||
+		!x.Tls || x.Sni != "www.example.com" || len(x.Alpn) != 2 || !x.AllowInsecure {
+		t.Errorf("parse vless link failed: %v", x)
+	}
+
+	if _, err = ParseNodeConn(p); err != nil {
+		t.Error(err)
+	}
+
+	p, err = DefaultVless.ParseLinkManual([]byte("vless://b831381d63244d53ad4f8cda48b30811@example.com"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x = p.GetVless(); x.Port != "443" || x.Tls || x.Net != "" || p.NOrigin != Point_manual {
+		t.Errorf("want the default port and tcp, got %v", p)
+	}
+
+	p, err = parseUrl([]byte("vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com?security=tls&type=grpc&serviceName=vless"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x = p.GetVless(); x.Net != "grpc" || x.Path != "vless" || !x.Tls {
+		t.Errorf("want the grpc service name, got %v", x)
+	}
+	if _, err = ParseNodeConn(p); err != nil {
+		t.Error(err)
+	}
+
+	for _, s := range []string{
+		"vless://example.com:443",
+		"vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:443?flow=xtls-rprx-vision",
+		"vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:443?security=reality",
+		"vless://b831381d-6324-4d53-ad4f-8cda48b30811@example.com:443?type=h2",
+	} {
+		if _, err = parseUrl([]byte(s), "test"); err == nil {
+			t.Errorf("want the error of %s", s)
+		}
+	}
+}